@@ -1,16 +0,0 @@
-package logger
-
-import (
-	"github.com/sirupsen/logrus"
-	"os"
-)
-
-func NewLogger() *logrus.Logger {
-	log := logrus.New()
-	log.Out = os.Stdout
-	log.SetLevel(logrus.InfoLevel)
-	log.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp: true,
-	})
-	return log
-}
@@ -2,18 +2,37 @@ package main
 
 import (
 	"GO_Plugin/src/config"
+	"GO_Plugin/src/plugin/snmp/trap"
 	"GO_Plugin/src/server"
 	"GO_Plugin/src/util"
+	"context"
+	"os/signal"
+	"syscall"
 )
 
 // main is the entry point of the application. It initializes logging, loads configuration, and starts the ZeroMQ polling engine.
+// A SIGINT/SIGTERM cancels the context passed to every subsystem, so workers
+// drain in-flight requests and sockets close via deferred cleanup instead of
+// being killed mid-request.
 func main() {
 
-	log := util.NewLogger()
-
 	cfg := config.LoadConfig()
 
+	log := util.NewLogger(cfg)
+
 	log.Info("Starting Polling Engine...")
 
-	server.StartPull(cfg, log)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	defer stop()
+
+	go func() {
+
+		if err := trap.Start(ctx, cfg, log); err != nil {
+
+			log.Errorf("Trap listener exited: %v", err)
+		}
+	}()
+
+	server.StartPull(ctx, cfg, log)
 }
@@ -1,16 +1,155 @@
 package config
 
+import (
+	"os"
+	"strconv"
+)
+
 type Config struct {
-	ZMQPort           string
-	VertxResponsePort string
-	VertxHost         string
+	ZMQPort             string
+	VertxResponsePort   string
+	VertxHost           string
+	TrapPort            string
+	TrapCommunity       string
+	TrapSecurityLevel   string
+	TrapSecurityName    string
+	TrapAuthProtocol    string
+	TrapAuthPassword    string
+	TrapPrivProtocol    string
+	TrapPrivPassword    string
+	DiscoveryWorkerPool int
+	PollingWorkerPool   int
+	TargetRateLimit     float64
+	TargetBurst         int
+	TargetMaxInFlight   int
+	MaxOIDsPerPDU       int
+	BulkMaxRepetitions  uint32
+	BulkNonRepeaters    int
+	DefaultTimeoutMs    int
+	SnmpTranslatePath   string
+	SnmpTablePath       string
+	MibDirs             string
+	LogSinkType         string
+	LogLevel            string
+	LogFormat           string
+	LogFilename         string
+	LogMaxSize          int
+	LogMaxBackups       int
+	LogMaxAge           int
+	LogCompress         bool
+	LogConsoleStream    string
+	LogAddress          string
 }
 
-// LoadConfig loads configuration from environment variables
+// LoadConfig loads configuration from environment variables, falling back to
+// the listed defaults for anything unset. This is what lets a deployment
+// redirect the log sink (GO_PLUGIN_LOG_FILENAME, GO_PLUGIN_LOG_SINK_TYPE,
+// ...) or any other setting without a recompile.
 func LoadConfig() *Config {
 	return &Config{
-		ZMQPort:           "5555",
-		VertxResponsePort: "5556",
-		VertxHost:         "localhost",
+		ZMQPort:             getEnvString("GO_PLUGIN_ZMQ_PORT", "5555"),
+		VertxResponsePort:   getEnvString("GO_PLUGIN_VERTX_RESPONSE_PORT", "5556"),
+		VertxHost:           getEnvString("GO_PLUGIN_VERTX_HOST", "localhost"),
+		TrapPort:            getEnvString("GO_PLUGIN_TRAP_PORT", "162"),
+		TrapCommunity:       getEnvString("GO_PLUGIN_TRAP_COMMUNITY", "public"),
+		TrapSecurityLevel:   getEnvString("GO_PLUGIN_TRAP_SECURITY_LEVEL", ""),
+		TrapSecurityName:    getEnvString("GO_PLUGIN_TRAP_SECURITY_NAME", ""),
+		TrapAuthProtocol:    getEnvString("GO_PLUGIN_TRAP_AUTH_PROTOCOL", ""),
+		TrapAuthPassword:    getEnvString("GO_PLUGIN_TRAP_AUTH_PASSWORD", ""),
+		TrapPrivProtocol:    getEnvString("GO_PLUGIN_TRAP_PRIV_PROTOCOL", ""),
+		TrapPrivPassword:    getEnvString("GO_PLUGIN_TRAP_PRIV_PASSWORD", ""),
+		DiscoveryWorkerPool: getEnvInt("GO_PLUGIN_DISCOVERY_WORKER_POOL", 10),
+		PollingWorkerPool:   getEnvInt("GO_PLUGIN_POLLING_WORKER_POOL", 100),
+		TargetRateLimit:     getEnvFloat64("GO_PLUGIN_TARGET_RATE_LIMIT", 20),
+		TargetBurst:         getEnvInt("GO_PLUGIN_TARGET_BURST", 5),
+		TargetMaxInFlight:   getEnvInt("GO_PLUGIN_TARGET_MAX_IN_FLIGHT", 4),
+		MaxOIDsPerPDU:       getEnvInt("GO_PLUGIN_MAX_OIDS_PER_PDU", 50),
+		BulkMaxRepetitions:  uint32(getEnvInt("GO_PLUGIN_BULK_MAX_REPETITIONS", 25)),
+		BulkNonRepeaters:    getEnvInt("GO_PLUGIN_BULK_NON_REPEATERS", 0),
+		DefaultTimeoutMs:    getEnvInt("GO_PLUGIN_DEFAULT_TIMEOUT_MS", 5000),
+		SnmpTranslatePath:   getEnvString("GO_PLUGIN_SNMP_TRANSLATE_PATH", "snmptranslate"),
+		SnmpTablePath:       getEnvString("GO_PLUGIN_SNMP_TABLE_PATH", "snmptable"),
+		MibDirs:             getEnvString("GO_PLUGIN_MIB_DIRS", ""),
+		LogSinkType:         getEnvString("GO_PLUGIN_LOG_SINK_TYPE", "filesystem"),
+		LogLevel:            getEnvString("GO_PLUGIN_LOG_LEVEL", "info"),
+		LogFormat:           getEnvString("GO_PLUGIN_LOG_FORMAT", "json"),
+		LogFilename:         getEnvString("GO_PLUGIN_LOG_FILENAME", "/home/jenil/Documents/logs/pluginlogs/app.log"),
+		LogMaxSize:          getEnvInt("GO_PLUGIN_LOG_MAX_SIZE", 50),
+		LogMaxBackups:       getEnvInt("GO_PLUGIN_LOG_MAX_BACKUPS", 5),
+		LogMaxAge:           getEnvInt("GO_PLUGIN_LOG_MAX_AGE", 7),
+		LogCompress:         getEnvBool("GO_PLUGIN_LOG_COMPRESS", true),
+		LogConsoleStream:    getEnvString("GO_PLUGIN_LOG_CONSOLE_STREAM", "stdout"),
+		LogAddress:          getEnvString("GO_PLUGIN_LOG_ADDRESS", ""),
+	}
+}
+
+// getEnvString reads key from the environment, falling back to def when unset.
+func getEnvString(key, def string) string {
+
+	if v, ok := os.LookupEnv(key); ok {
+
+		return v
+	}
+
+	return def
+}
+
+// getEnvInt reads key as an int, falling back to def when unset or unparsable.
+func getEnvInt(key string, def int) int {
+
+	v, ok := os.LookupEnv(key)
+
+	if !ok {
+
+		return def
+	}
+
+	n, err := strconv.Atoi(v)
+
+	if err != nil {
+
+		return def
+	}
+
+	return n
+}
+
+// getEnvFloat64 reads key as a float64, falling back to def when unset or unparsable.
+func getEnvFloat64(key string, def float64) float64 {
+
+	v, ok := os.LookupEnv(key)
+
+	if !ok {
+
+		return def
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+
+	if err != nil {
+
+		return def
+	}
+
+	return f
+}
+
+// getEnvBool reads key as a bool, falling back to def when unset or unparsable.
+func getEnvBool(key string, def bool) bool {
+
+	v, ok := os.LookupEnv(key)
+
+	if !ok {
+
+		return def
 	}
+
+	b, err := strconv.ParseBool(v)
+
+	if err != nil {
+
+		return def
+	}
+
+	return b
 }
@@ -0,0 +1,500 @@
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"GO_Plugin/src/plugin/snmp/convert"
+	"github.com/gosnmp/gosnmp"
+)
+
+// Request keys for SNMPv3 credentials, shared by polling and discovery, plus
+// the GETBULK MaxRepetitions override, which only the polling client reads.
+const (
+	SecurityLevel  = "securityLevel"
+	SecurityName   = "securityName"
+	AuthProtocol   = "authProtocol"
+	AuthPassword   = "authPassword"
+	PrivProtocol   = "privProtocol"
+	PrivPassword   = "privPassword"
+	ContextName    = "contextName"
+	MaxRepetitions = "maxRepetitions"
+
+	defaultBulkMaxRepetitions = 25
+	defaultBulkNonRepeaters   = 0
+
+	defaultMaxOIDsPerPDU = 50
+
+	defaultSNMPPort = 161
+)
+
+// maxOIDsPerPDU caps how many OIDs are packed into a single GETs PDU,
+// overridable at startup via ConfigureMaxOIDsPerPDU.
+var maxOIDsPerPDU = defaultMaxOIDsPerPDU
+
+// bulkMaxRepetitions and bulkNonRepeaters are the default GETBULK parameters
+// used for every table/column walk (walkTable, getInterfaces) when a request
+// doesn't override MaxRepetitions itself. bulkNonRepeaters defaults to 0 because
+// every walked OID is a repeating (table) varbind, never a leading
+// non-repeating one. Both are overridable at startup via ConfigureBulkWalk.
+var bulkMaxRepetitions uint32 = defaultBulkMaxRepetitions
+var bulkNonRepeaters = defaultBulkNonRepeaters
+
+// ConfigureMaxOIDsPerPDU sets the default OID batch size used by getBatched.
+// It should be called once at startup, before any SNMP requests are served.
+func ConfigureMaxOIDsPerPDU(n int) {
+
+	if n > 0 {
+
+		maxOIDsPerPDU = n
+	}
+}
+
+// ConfigureBulkWalk sets the default GETBULK max-repetitions and
+// non-repeaters used by walkTable and getInterfaces. It should be called
+// once at startup, before any SNMP requests are served.
+func ConfigureBulkWalk(maxRepetitions uint32, nonRepeaters int) {
+
+	if maxRepetitions > 0 {
+
+		bulkMaxRepetitions = maxRepetitions
+	}
+
+	bulkNonRepeaters = nonRepeaters
+}
+
+// getBatched performs g.Get over oids in chunks of at most maxOIDsPerPDU,
+// concatenating the resulting variables in order, so callers with wide OID
+// lists (e.g. a profile's full scalar field list) don't exceed a device's PDU
+// size limit.
+func getBatched(g *gosnmp.GoSNMP, oids []string) ([]gosnmp.SnmpPDU, error) {
+
+	var variables []gosnmp.SnmpPDU
+
+	for start := 0; start < len(oids); start += maxOIDsPerPDU {
+
+		end := start + maxOIDsPerPDU
+
+		if end > len(oids) {
+
+			end = len(oids)
+		}
+
+		result, err := g.Get(oids[start:end])
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		variables = append(variables, result.Variables...)
+	}
+
+	return variables, nil
+}
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":    gosnmp.DES,
+	"AES":    gosnmp.AES,
+	"AES192": gosnmp.AES192,
+	"AES256": gosnmp.AES256,
+}
+
+var securityLevels = map[string]gosnmp.SnmpV3MsgFlags{
+	"noAuthNoPriv": gosnmp.NoAuthNoPriv,
+	"authNoPriv":   gosnmp.AuthNoPriv,
+	"authPriv":     gosnmp.AuthPriv,
+}
+
+// buildSNMPClient constructs a *gosnmp.GoSNMP from an inbound polling
+// request (FetchSNMPData, one call per target). Discovery builds its own
+// client, taking the port from the request's "port" field, and shares only
+// applyV3Params with it. It does not call Connect. ctx is wired onto g.Context so a
+// per-request deadline cancels the Connect call and every Get/BulkWalk made
+// through g.
+func buildSNMPClient(ctx context.Context, ip, community, version string, reqData map[string]interface{}) (*gosnmp.GoSNMP, error) {
+
+	host, port, err := parseAgentAddress(ip)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	g := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      port,
+		Community: community,
+		Timeout:   time.Millisecond * 500,
+		Retries:   1,
+		Context:   ctx,
+	}
+
+	switch version {
+
+	case "1":
+		g.Version = gosnmp.Version1
+
+	case "2", "2c":
+		g.Version = gosnmp.Version2c
+
+	case "3":
+		g.Version = gosnmp.Version3
+
+		if err := applyV3Params(g, reqData); err != nil {
+
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf(UnsupportedSNMP)
+	}
+
+	g.MaxRepetitions = bulkMaxRepetitions
+
+	if raw, ok := reqData[MaxRepetitions]; ok {
+
+		if v, ok := raw.(float64); ok && v > 0 {
+
+			g.MaxRepetitions = uint32(v)
+		}
+	}
+
+	g.NonRepeaters = bulkNonRepeaters
+
+	return g, nil
+}
+
+// parseAgentAddress parses a single-target "ip" or "agents" entry into a bare
+// host and numeric port, so a multi-agent request can address each agent as
+// "udp://host:port" (or "host:port", or a bare host) the way the request
+// itself documents. A missing scheme or port is fine: the scheme is only
+// ever stripped, and a missing port falls back to defaultSNMPPort.
+func parseAgentAddress(addr string) (host string, port uint16, err error) {
+
+	if idx := strings.Index(addr, "://"); idx != -1 {
+
+		addr = addr[idx+len("://"):]
+	}
+
+	h, p, splitErr := net.SplitHostPort(addr)
+
+	if splitErr != nil {
+
+		return addr, defaultSNMPPort, nil
+	}
+
+	portNum, err := strconv.Atoi(p)
+
+	if err != nil {
+
+		return "", 0, fmt.Errorf("invalid port %q in agent address %q: %w", p, addr, err)
+	}
+
+	return h, uint16(portNum), nil
+}
+
+// V3CredentialError reports a missing or unsupported SNMPv3 USM credential
+// for the requested securityLevel, so callers can distinguish a
+// configuration mistake from a transport-level connect failure.
+type V3CredentialError struct {
+	SecurityLevel string
+	Reason        string
+}
+
+func (e *V3CredentialError) Error() string {
+
+	return fmt.Sprintf("SNMPv3 securityLevel %q: %s", e.SecurityLevel, e.Reason)
+}
+
+// applyV3Params reads the SNMPv3 credential keys from reqData and wires a
+// gosnmp.UsmSecurityParameters onto g, failing fast if the credentials
+// required for the requested security level are missing. Engine ID discovery
+// is handled by gosnmp itself during Connect and needs no help here.
+func applyV3Params(g *gosnmp.GoSNMP, reqData map[string]interface{}) error {
+
+	secLevelName, _ := reqData[SecurityLevel].(string)
+
+	secName, _ := reqData[SecurityName].(string)
+
+	authProtoName, _ := reqData[AuthProtocol].(string)
+
+	authPassword, _ := reqData[AuthPassword].(string)
+
+	privProtoName, _ := reqData[PrivProtocol].(string)
+
+	privPassword, _ := reqData[PrivPassword].(string)
+
+	usm, msgFlags, err := BuildUSM(secLevelName, secName, authProtoName, authPassword, privProtoName, privPassword)
+
+	if err != nil {
+
+		return err
+	}
+
+	g.Version = gosnmp.Version3
+
+	g.SecurityModel = gosnmp.UserSecurityModel
+
+	g.MsgFlags = msgFlags
+
+	g.SecurityParameters = usm
+
+	if contextName, ok := reqData[ContextName].(string); ok {
+
+		g.ContextName = contextName
+	}
+
+	return nil
+}
+
+// BuildUSM resolves a set of discrete SNMPv3 USM credential fields into
+// gosnmp security parameters and message flags, failing with a
+// *V3CredentialError if securityLevel demands a protocol/password that is
+// missing or unrecognized. It is shared by applyV3Params (per-request
+// polling credentials) and the trap listener's static credential store.
+func BuildUSM(securityLevel, securityName, authProtocol, authPassword, privProtocol, privPassword string) (*gosnmp.UsmSecurityParameters, gosnmp.SnmpV3MsgFlags, error) {
+
+	msgFlags, ok := securityLevels[securityLevel]
+
+	if !ok {
+
+		return nil, 0, &V3CredentialError{SecurityLevel: securityLevel, Reason: fmt.Sprintf("unsupported or missing %q", SecurityLevel)}
+	}
+
+	usm := &gosnmp.UsmSecurityParameters{UserName: securityName}
+
+	if msgFlags == gosnmp.AuthNoPriv || msgFlags == gosnmp.AuthPriv {
+
+		authProto, ok := authProtocols[authProtocol]
+
+		if !ok || authPassword == "" {
+
+			return nil, 0, &V3CredentialError{SecurityLevel: securityLevel, Reason: "authProtocol/authPassword required"}
+		}
+
+		usm.AuthenticationProtocol = authProto
+
+		usm.AuthenticationPassphrase = authPassword
+	}
+
+	if msgFlags == gosnmp.AuthPriv {
+
+		privProto, ok := privProtocols[privProtocol]
+
+		if !ok || privPassword == "" {
+
+			return nil, 0, &V3CredentialError{SecurityLevel: securityLevel, Reason: "privProtocol/privPassword required"}
+		}
+
+		usm.PrivacyProtocol = privProto
+
+		usm.PrivacyPassphrase = privPassword
+	}
+
+	return usm, msgFlags, nil
+}
+
+// walkTable walks a declared SNMP table using GETBULK (falling back to
+// GETNEXT for v1, which does not support GETBULK) and joins the resulting
+// columns into one row per trailing OID index.
+func walkTable(g *gosnmp.GoSNMP, table TableDef) ([]map[string]interface{}, error) {
+
+	rows := make(map[string]map[string]interface{})
+
+	var order []string
+
+	for _, field := range table.Fields {
+
+		walkFn := func(pdu gosnmp.SnmpPDU) error {
+
+			index := oidSuffix(pdu.Name, field.OID)
+
+			row, ok := rows[index]
+
+			if !ok {
+
+				row = make(map[string]interface{})
+
+				if table.IndexAsTag {
+
+					row[Index] = index
+				}
+
+				rows[index] = row
+
+				order = append(order, index)
+			}
+
+			row[field.Name] = convertValue(pdu, field.Conversion, field.EnumMap)
+
+			if field.MIBName != "" {
+
+				row[field.Name+".mibName"] = field.MIBName
+			}
+
+			return nil
+		}
+
+		var err error
+
+		if g.Version == gosnmp.Version1 {
+
+			err = g.Walk(field.OID, walkFn)
+
+		} else {
+
+			err = g.BulkWalk(field.OID, walkFn)
+		}
+
+		if err != nil {
+
+			return nil, fmt.Errorf("walking %s (%s): %w", field.Name, field.OID, err)
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(order))
+
+	for _, index := range order {
+
+		result = append(result, rows[index])
+	}
+
+	return result, nil
+}
+
+// collectProfile gathers every scalar field and table declared on a
+// CollectionProfile, returning one flat map keyed by field/table name. Table
+// rows inherit the listed scalar field values as InheritTags.
+func collectProfile(g *gosnmp.GoSNMP, profile *CollectionProfile) map[string]interface{} {
+
+	data := make(map[string]interface{})
+
+	if len(profile.Fields) > 0 {
+
+		oids := make([]string, len(profile.Fields))
+
+		for i, field := range profile.Fields {
+
+			oids[i] = field.OID
+		}
+
+		variables, err := getBatched(g, oids)
+
+		if err != nil {
+
+			data[Errors] = fmt.Sprintf("error fetching fields: %s", err)
+
+		} else {
+
+			for i, variable := range variables {
+
+				field := profile.Fields[i]
+
+				data[field.Name] = convertValue(variable, field.Conversion, field.EnumMap)
+
+				if field.MIBName != "" {
+
+					data[field.Name+".mibName"] = field.MIBName
+				}
+			}
+		}
+	}
+
+	for _, table := range profile.Tables {
+
+		rows, err := walkTable(g, table)
+
+		if err != nil {
+
+			data[table.Name+".error"] = err.Error()
+
+			continue
+		}
+
+		for _, tag := range table.InheritTags {
+
+			if value, ok := data[tag]; ok {
+
+				for _, row := range rows {
+
+					row[tag] = value
+				}
+			}
+		}
+
+		data[table.Name] = rows
+
+		if table.MIBName != "" {
+
+			data[table.Name+".mibName"] = table.MIBName
+		}
+	}
+
+	return data
+}
+
+// oidSuffix trims the column OID prefix from a returned OID, leaving the
+// table index (e.g. "1.3.6.1.2.1.2.2.1.2.10" with column "...2.2.1.2" -> "10").
+func oidSuffix(returned, column string) string {
+
+	trimmed := strings.TrimPrefix(returned, ".")
+
+	column = strings.TrimPrefix(column, ".")
+
+	if strings.HasPrefix(trimmed, column+".") {
+
+		return trimmed[len(column)+1:]
+	}
+
+	parts := strings.Split(trimmed, ".")
+
+	return parts[len(parts)-1]
+}
+
+// convertValue resolves the named conversion (see snmp/convert) and applies
+// it to a raw varbind, falling back to the declared-name-less default
+// conversion if the name is unknown or the conversion itself fails.
+func convertValue(pdu gosnmp.SnmpPDU, conversion string, enumMap map[string]string) interface{} {
+
+	if pdu.Value == nil {
+
+		return nil
+	}
+
+	converter, err := convert.Lookup(conversion, enumMap)
+
+	if err != nil {
+
+		log.Printf("snmp: %v, falling back to default conversion", err)
+
+		converter, _ = convert.Lookup("", nil)
+	}
+
+	value, err := converter.Convert(pdu)
+
+	if err != nil {
+
+		log.Printf("snmp: conversion %q failed: %v, falling back to default conversion", conversion, err)
+
+		fallback, _ := convert.Lookup("", nil)
+
+		value, _ = fallback.Convert(pdu)
+	}
+
+	return value
+}
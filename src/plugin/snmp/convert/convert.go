@@ -0,0 +1,261 @@
+// Package convert implements the pluggable value-conversion pipeline applied
+// to raw SNMP varbinds before they are stored in a response. Conversions are
+// resolved by name ("hwaddr", "ipaddr", "hextoint:BigEndian:uint64",
+// "float(3)", "enum", "timeticks") through Lookup.
+package convert
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// Converter transforms a raw SNMP varbind into the value that should be
+// stored in the response.
+type Converter interface {
+	Convert(pdu gosnmp.SnmpPDU) (interface{}, error)
+}
+
+// ConverterFunc adapts a plain function to the Converter interface.
+type ConverterFunc func(pdu gosnmp.SnmpPDU) (interface{}, error)
+
+func (f ConverterFunc) Convert(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+	return f(pdu)
+}
+
+// Lookup resolves a conversion name to a Converter. enumLabels is only
+// consulted for the "enum" conversion, since its value->label map is
+// declared alongside the field rather than encoded in the name itself.
+func Lookup(name string, enumLabels map[string]string) (Converter, error) {
+
+	switch {
+
+	case name == "":
+		return ConverterFunc(defaultConverter), nil
+
+	case name == "raw":
+		return ConverterFunc(rawConverter), nil
+
+	case name == "hwaddr":
+		return ConverterFunc(hwAddrConverter), nil
+
+	case name == "ipaddr":
+		return ConverterFunc(ipAddrConverter), nil
+
+	case name == "timeticks":
+		return ConverterFunc(timeTicksConverter), nil
+
+	case name == "enum":
+		return enumConverter(enumLabels), nil
+
+	case strings.HasPrefix(name, "float("):
+		return floatConverter(name)
+
+	case strings.HasPrefix(name, "hextoint:"):
+		return hexToIntConverter(name)
+
+	default:
+		return nil, fmt.Errorf("unknown conversion %q", name)
+	}
+}
+
+// rawConverter passes the decoded gosnmp value through completely unchanged,
+// which already preserves Counter32/Counter64/Gauge32/TimeTicks as their
+// native unsigned Go types and signed Integer32 as int.
+func rawConverter(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+	return pdu.Value, nil
+}
+
+// defaultConverter is applied when no conversion is declared: it behaves
+// like rawConverter except OctetString values are coerced to a Go string,
+// matching the collector's historical no-conversion behavior.
+func defaultConverter(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+	if b, ok := pdu.Value.([]byte); ok {
+
+		return string(b), nil
+	}
+
+	return pdu.Value, nil
+}
+
+// hwAddrConverter formats an OctetString value as a colon-separated MAC address.
+func hwAddrConverter(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+	b, ok := pdu.Value.([]byte)
+
+	if !ok {
+
+		return nil, fmt.Errorf("hwaddr: expected []byte value, got %T", pdu.Value)
+	}
+
+	return net.HardwareAddr(b).String(), nil
+}
+
+// ipAddrConverter formats an IpAddress value (4-byte OctetString or already
+// a dotted string) as a dotted-decimal string.
+func ipAddrConverter(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+	switch v := pdu.Value.(type) {
+
+	case string:
+		return v, nil
+
+	case []byte:
+		return net.IP(v).String(), nil
+
+	default:
+		return nil, fmt.Errorf("ipaddr: unsupported value type %T", pdu.Value)
+	}
+}
+
+// timeTicksConverter formats a TimeTicks value (hundredths of a second) as a
+// human-readable duration string.
+func timeTicksConverter(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+	ticks, ok := pdu.Value.(uint32)
+
+	if !ok {
+
+		return nil, fmt.Errorf("timeticks: expected uint32 value, got %T", pdu.Value)
+	}
+
+	return (time.Duration(ticks) * 10 * time.Millisecond).String(), nil
+}
+
+// enumConverter maps a numeric value to a caller-supplied label (e.g.
+// ifOperStatus 1 -> "up"), falling back to the raw value if no label matches.
+func enumConverter(labels map[string]string) Converter {
+
+	return ConverterFunc(func(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+		key := fmt.Sprintf("%v", pdu.Value)
+
+		if label, ok := labels[key]; ok {
+
+			return label, nil
+		}
+
+		return pdu.Value, nil
+	})
+}
+
+// floatConverter parses a "float(N)" conversion name and rounds a numeric
+// value to N decimal places.
+func floatConverter(name string) (Converter, error) {
+
+	inside := strings.TrimSuffix(strings.TrimPrefix(name, "float("), ")")
+
+	precision, err := strconv.Atoi(inside)
+
+	if err != nil {
+
+		return nil, fmt.Errorf("invalid float conversion %q: %w", name, err)
+	}
+
+	scale := math.Pow(10, float64(precision))
+
+	return ConverterFunc(func(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+		var raw float64
+
+		switch v := pdu.Value.(type) {
+
+		case int:
+			raw = float64(v)
+
+		case uint:
+			raw = float64(v)
+
+		case uint32:
+			raw = float64(v)
+
+		case uint64:
+			raw = float64(v)
+
+		case float64:
+			raw = v
+
+		default:
+			return nil, fmt.Errorf("float: unsupported value type %T", pdu.Value)
+		}
+
+		return math.Round(raw*scale) / scale, nil
+	}), nil
+}
+
+// hexToIntConverter parses a "hextoint:<ByteOrder>:<width>" conversion name
+// and decodes an OctetString value as an unsigned integer of that width.
+func hexToIntConverter(name string) (Converter, error) {
+
+	parts := strings.Split(name, ":")
+
+	if len(parts) != 3 {
+
+		return nil, fmt.Errorf("invalid hextoint conversion %q, expected hextoint:<ByteOrder>:<width>", name)
+	}
+
+	var order binary.ByteOrder
+
+	switch parts[1] {
+
+	case "BigEndian":
+		order = binary.BigEndian
+
+	case "LittleEndian":
+		order = binary.LittleEndian
+
+	default:
+		return nil, fmt.Errorf("unsupported byte order %q", parts[1])
+	}
+
+	width := parts[2]
+
+	return ConverterFunc(func(pdu gosnmp.SnmpPDU) (interface{}, error) {
+
+		b, ok := pdu.Value.([]byte)
+
+		if !ok {
+
+			return nil, fmt.Errorf("hextoint: expected []byte value, got %T", pdu.Value)
+		}
+
+		switch width {
+
+		case "uint16":
+			if len(b) < 2 {
+
+				return nil, fmt.Errorf("hextoint: need at least 2 bytes, got %d", len(b))
+			}
+
+			return order.Uint16(b), nil
+
+		case "uint32":
+			if len(b) < 4 {
+
+				return nil, fmt.Errorf("hextoint: need at least 4 bytes, got %d", len(b))
+			}
+
+			return order.Uint32(b), nil
+
+		case "uint64":
+			if len(b) < 8 {
+
+				return nil, fmt.Errorf("hextoint: need at least 8 bytes, got %d", len(b))
+			}
+
+			return order.Uint64(b), nil
+
+		default:
+			return nil, fmt.Errorf("unsupported hextoint width %q", width)
+		}
+	}), nil
+}
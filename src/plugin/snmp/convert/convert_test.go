@@ -0,0 +1,142 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestLookupDefaultStringifiesOctetString(t *testing.T) {
+
+	converter, err := Lookup("", nil)
+
+	if err != nil {
+
+		t.Fatalf("Lookup(\"\"): %v", err)
+	}
+
+	got, err := converter.Convert(gosnmp.SnmpPDU{Value: []byte("eth0")})
+
+	if err != nil {
+
+		t.Fatalf("Convert: %v", err)
+	}
+
+	if got != "eth0" {
+
+		t.Errorf("got %v, want %q", got, "eth0")
+	}
+}
+
+func TestLookupRawPassesValueThrough(t *testing.T) {
+
+	converter, err := Lookup("raw", nil)
+
+	if err != nil {
+
+		t.Fatalf("Lookup(\"raw\"): %v", err)
+	}
+
+	got, err := converter.Convert(gosnmp.SnmpPDU{Value: uint32(42)})
+
+	if err != nil {
+
+		t.Fatalf("Convert: %v", err)
+	}
+
+	if got != uint32(42) {
+
+		t.Errorf("got %v, want %v", got, uint32(42))
+	}
+}
+
+func TestHwAddrConverter(t *testing.T) {
+
+	converter, err := Lookup("hwaddr", nil)
+
+	if err != nil {
+
+		t.Fatalf("Lookup(\"hwaddr\"): %v", err)
+	}
+
+	got, err := converter.Convert(gosnmp.SnmpPDU{Value: []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}})
+
+	if err != nil {
+
+		t.Fatalf("Convert: %v", err)
+	}
+
+	if got != "aa:bb:cc:dd:ee:ff" {
+
+		t.Errorf("got %v, want %q", got, "aa:bb:cc:dd:ee:ff")
+	}
+}
+
+func TestEnumConverterFallsBackToRawValue(t *testing.T) {
+
+	converter := enumConverter(map[string]string{"1": "up"})
+
+	got, err := converter.Convert(gosnmp.SnmpPDU{Value: 2})
+
+	if err != nil {
+
+		t.Fatalf("Convert: %v", err)
+	}
+
+	if got != 2 {
+
+		t.Errorf("got %v, want unmapped raw value 2", got)
+	}
+}
+
+func TestFloatConverterRoundsToDeclaredPrecision(t *testing.T) {
+
+	converter, err := Lookup("float(2)", nil)
+
+	if err != nil {
+
+		t.Fatalf("Lookup(\"float(2)\"): %v", err)
+	}
+
+	got, err := converter.Convert(gosnmp.SnmpPDU{Value: float64(1.2356)})
+
+	if err != nil {
+
+		t.Fatalf("Convert: %v", err)
+	}
+
+	if got != 1.24 {
+
+		t.Errorf("got %v, want 1.24", got)
+	}
+}
+
+func TestHexToIntConverter(t *testing.T) {
+
+	converter, err := Lookup("hextoint:BigEndian:uint32", nil)
+
+	if err != nil {
+
+		t.Fatalf("Lookup(\"hextoint:BigEndian:uint32\"): %v", err)
+	}
+
+	got, err := converter.Convert(gosnmp.SnmpPDU{Value: []byte{0x00, 0x00, 0x01, 0x00}})
+
+	if err != nil {
+
+		t.Fatalf("Convert: %v", err)
+	}
+
+	if got != uint32(256) {
+
+		t.Errorf("got %v, want 256", got)
+	}
+}
+
+func TestLookupUnknownConversion(t *testing.T) {
+
+	if _, err := Lookup("does-not-exist", nil); err == nil {
+
+		t.Fatal("expected an error for an unknown conversion name")
+	}
+}
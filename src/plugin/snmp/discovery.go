@@ -1,6 +1,9 @@
 package snmp
 
 import (
+	"GO_Plugin/src/server/protocol"
+	"context"
+	"errors"
 	"github.com/gosnmp/gosnmp"
 	"log"
 	"strings"
@@ -18,43 +21,58 @@ const (
 	Data               = "data"
 	SystemName         = "systemName"
 	SNMPPlugin         = "snmp"
-	Fail               = "fail"
-	Success            = "success"
 	UnsupportedPlugin  = "unsupported plugin type"
 	UnsupportedSNMP    = "unsupported SNMP version"
 	SNMPConnectFail    = "SNMP connection failed"
 	SNMPGetFail        = "SNMP get request failed"
 	SystemNameNotFound = "system name not found"
+	FieldMissing       = "missing required field"
 	SNMPConnectMsg     = "Connecting to SNMP device at %s"
 	SNMPGetMsg         = "Performing SNMP GET request on %s"
 	SysemNameOid       = "1.3.6.1.2.1.1.5.0"
 	Errors             = "error"
 	Port               = "port"
+
+	// TimeoutMs is the optional per-request deadline, in milliseconds, that
+	// server's requestContext reads off an inbound request to derive the context
+	// passed into Discovery/FetchSNMPData. Requests that omit it fall back
+	// to the configured default.
+	TimeoutMs = "timeoutMs"
 )
 
-// Discovery performs SNMP discovery for a given network device.
-// It validates the request, establishes an SNMP connection, and retrieves system information.
+// Discovery performs SNMP discovery for a given network device: it validates the request,
+// establishes an SNMP connection, and retrieves the device's system name.
+// @param ctx context.Context - Governs cancellation/deadline of the SNMP connect and get; propagated onto the gosnmp client's Context.
 // @param reqData map[string]interface{} - A map containing request data including IP, community, and SNMP version.
-// If validation fails, error messages and status updates are stored in reqData.
-func Discovery(reqData map[string]interface{}) {
+// @return protocol.Result - A {"systemName": ...} map on success.
+// @return *protocol.ProtocolError - Describes why discovery failed; nil on success.
+func Discovery(ctx context.Context, reqData map[string]interface{}) (protocol.Result, *protocol.ProtocolError) {
 
-	if reqData[PluginType] != SNMPPlugin {
+	if !ValidateRequest(reqData) {
 
-		reqData[Errors] = UnsupportedPlugin
+		return nil, &protocol.ProtocolError{Code: protocol.MissingField, Message: FieldMissing}
+	}
 
-		reqData[Status] = Fail
+	if reqData[PluginType] != SNMPPlugin {
 
 		log.Println(UnsupportedPlugin)
 
-		return
+		return nil, &protocol.ProtocolError{Code: protocol.InvalidRequest, Message: UnsupportedPlugin}
 	}
 
+	ip, _ := reqData[IP].(string)
+
+	port, _ := reqData[Port].(int)
+
+	community, _ := reqData[Community].(string)
+
 	snmp := &gosnmp.GoSNMP{
-		Target:    reqData[IP].(string),
-		Port:      uint16(reqData[Port].(float64)),
-		Community: reqData[Community].(string),
+		Target:    ip,
+		Port:      uint16(port),
+		Community: community,
 		Timeout:   time.Millisecond * 500,
 		Retries:   0,
+		Context:   ctx,
 	}
 
 	switch reqData[Version].(string) {
@@ -68,29 +86,29 @@ func Discovery(reqData map[string]interface{}) {
 	case "3":
 		snmp.Version = gosnmp.Version3
 
-	default:
-		reqData[Errors] = UnsupportedSNMP
+		if err := applyV3Params(snmp, reqData); err != nil {
 
-		reqData[Status] = Fail
+			return nil, &protocol.ProtocolError{Code: protocol.SNMPAuth, Message: err.Error()}
+		}
 
+	default:
 		log.Println(UnsupportedSNMP)
 
-		return
+		return nil, &protocol.ProtocolError{Code: protocol.InvalidRequest, Message: UnsupportedSNMP}
 	}
 
-	log.Printf(SNMPConnectMsg, reqData[IP].(string))
-
-	err := snmp.Connect()
+	log.Printf(SNMPConnectMsg, ip)
 
-	if err != nil {
+	if err := snmp.Connect(); err != nil {
 
-		reqData[Errors] = SNMPConnectFail
+		log.Println(SNMPConnectFail)
 
-		reqData[Status] = Fail
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 
-		log.Println(SNMPConnectFail)
+			return nil, &protocol.ProtocolError{Code: protocol.SNMPTimeout, Message: err.Error()}
+		}
 
-		return
+		return nil, &protocol.ProtocolError{Code: protocol.SNMPConnect, Message: SNMPConnectFail}
 	}
 
 	defer snmp.Conn.Close()
@@ -103,38 +121,36 @@ func Discovery(reqData map[string]interface{}) {
 
 	if err != nil {
 
-		reqData[Errors] = SNMPGetFail
-
-		reqData[Status] = Fail
-
-		return
+		return nil, &protocol.ProtocolError{Code: protocol.SNMPConnect, Message: SNMPGetFail}
 	}
 
 	for _, variable := range result.Variables {
 
 		if variable.Type == gosnmp.OctetString {
 
-			reqData[Data] = map[string]interface{}{SystemName: string(variable.Value.([]byte))}
-
-			reqData[Status] = Success
-
-			return
+			return map[string]interface{}{SystemName: string(variable.Value.([]byte))}, nil
 		}
 	}
 
-	reqData[Errors] = SystemNameNotFound
-
-	reqData[Status] = Fail
-
 	log.Println(SystemNameNotFound)
+
+	return nil, &protocol.ProtocolError{Code: protocol.SNMPNoSuchName, Message: SystemNameNotFound}
 }
 
 // ValidateRequest checks whether the required fields are present in the request data.
+// For version "3" requests, securityLevel and securityName are also required; authProtocol/
+// authPassword and privProtocol/privPassword are validated later, by applyV3Params, since what
+// is required there depends on the chosen securityLevel.
 // @param reqData map[string]interface{} - The request data containing key-value pairs.
 // @return bool - Returns true if all required fields are present, otherwise false.
 func ValidateRequest(reqData map[string]interface{}) bool {
 
-	requiredFields := []string{IP, PluginType, RequestID, Port}
+	requiredFields := []string{PluginType, RequestID, Port, Community, Version}
+
+	if rawAgents, hasAgents := reqData[Agents].([]interface{}); !hasAgents || len(rawAgents) == 0 {
+
+		requiredFields = append([]string{IP}, requiredFields...)
+	}
 
 	for _, field := range requiredFields {
 
@@ -162,5 +178,18 @@ func ValidateRequest(reqData map[string]interface{}) bool {
 		}
 	}
 
+	if version, _ := reqData[Version].(string); version == "3" {
+
+		for _, field := range []string{SecurityLevel, SecurityName} {
+
+			value, ok := reqData[field].(string)
+
+			if !ok || strings.TrimSpace(value) == "" {
+
+				return false
+			}
+		}
+	}
+
 	return true
 }
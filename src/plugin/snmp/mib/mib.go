@@ -0,0 +1,158 @@
+// Package mib resolves symbolic MIB names ("IF-MIB::ifTable",
+// "HOST-RESOURCES-MIB::hrMemorySize") to numeric OIDs by shelling out to the
+// net-snmp snmptranslate tool, so profile-driven requests don't have to hand
+// maintain numeric OID maps for well-known MIBs. It also exposes TableColumns,
+// which shells out to snmptable to list a table's column names, so a table
+// entry can have its fields derived from the table's own MIB definition
+// instead of listing every column by hand.
+package mib
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var numericOID = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)*$`)
+
+var (
+	mu = sync.Mutex{}
+
+	translatePath = "snmptranslate"
+
+	tablePath = "snmptable"
+
+	mibDirs = ""
+
+	cache = make(map[string]string)
+)
+
+// Configure sets the snmptranslate/snmptable binary paths and MIB search
+// directory (net-snmp's colon-separated -M argument) used by Resolve and
+// TableColumns. It should be called once at startup, before any request-time
+// resolution happens.
+func Configure(translateBin, tableBin, dirs string) {
+
+	mu.Lock()
+
+	defer mu.Unlock()
+
+	if translateBin != "" {
+
+		translatePath = translateBin
+	}
+
+	if tableBin != "" {
+
+		tablePath = tableBin
+	}
+
+	mibDirs = dirs
+}
+
+// IsSymbolic reports whether oidOrName looks like a symbolic MIB reference
+// ("IF-MIB::ifTable") rather than an already-numeric OID.
+func IsSymbolic(oidOrName string) bool {
+
+	return !numericOID.MatchString(strings.TrimSpace(oidOrName))
+}
+
+// Resolve returns the numeric OID for a MIB name, resolving and caching it
+// via snmptranslate on first use. If oidOrName is already numeric it is
+// returned with surrounding whitespace and any leading "." removed, and
+// Resolve never shells out.
+func Resolve(oidOrName string) (string, error) {
+
+	trimmed := strings.TrimSpace(oidOrName)
+
+	if !IsSymbolic(trimmed) {
+
+		return strings.TrimPrefix(trimmed, "."), nil
+	}
+
+	mu.Lock()
+
+	if oid, ok := cache[trimmed]; ok {
+
+		mu.Unlock()
+
+		return oid, nil
+	}
+
+	bin, dirs := translatePath, mibDirs
+
+	mu.Unlock()
+
+	args := []string{"-On"}
+
+	if dirs != "" {
+
+		args = append(args, "-M", dirs)
+	}
+
+	args = append(args, trimmed)
+
+	out, err := exec.Command(bin, args...).Output()
+
+	if err != nil {
+
+		return "", fmt.Errorf("resolving MIB name %q via %s: %w", trimmed, bin, err)
+	}
+
+	oid := strings.TrimSpace(string(out))
+
+	oid = strings.TrimPrefix(oid, ".")
+
+	if oid == "" || !numericOID.MatchString(oid) {
+
+		return "", fmt.Errorf("snmptranslate returned an unexpected result for %q: %q", trimmed, oid)
+	}
+
+	mu.Lock()
+
+	cache[trimmed] = oid
+
+	mu.Unlock()
+
+	return oid, nil
+}
+
+// TableColumns shells out to `snmptable -Ch -Cf , -Cl [-M <dirs>] <tableName>` to fetch a
+// table's column names in declaration order, for callers that want to derive
+// a table profile's fields from its MIB definition instead of listing every
+// column by hand.
+func TableColumns(tableName string) ([]string, error) {
+
+	mu.Lock()
+
+	bin, dirs := tablePath, mibDirs
+
+	mu.Unlock()
+
+	args := []string{"-Ch", "-Cf", ",", "-Cl"}
+
+	if dirs != "" {
+
+		args = append(args, "-M", dirs)
+	}
+
+	args = append(args, tableName)
+
+	out, err := exec.Command(bin, args...).Output()
+
+	if err != nil {
+
+		return nil, fmt.Errorf("listing columns for table %q via %s: %w", tableName, bin, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+
+	if len(lines) == 0 || lines[0] == "" {
+
+		return nil, fmt.Errorf("snmptable returned no header for table %q", tableName)
+	}
+
+	return strings.Split(lines[0], ","), nil
+}
@@ -0,0 +1,212 @@
+// Package model defines typed response shapes for SNMP system/interface
+// collection, as an alternative to the loosely-typed map[string]interface{}
+// built by the legacy collection path in polling.go. Response implements
+// json.Marshaler so existing consumers can opt back into the original flat
+// wire format via LegacyFormat.
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TimeTicks is an SNMP TimeTicks value: hundredths of a second since some
+// epoch, kept alongside a human-readable formatted duration.
+type TimeTicks struct {
+	Raw       uint32
+	Formatted string
+}
+
+// NewTimeTicks builds a TimeTicks from a raw SNMP TimeTicks value.
+func NewTimeTicks(raw uint32) TimeTicks {
+
+	seconds := raw / 100
+
+	days := seconds / (24 * 3600)
+
+	seconds %= 24 * 3600
+
+	hours := seconds / 3600
+
+	seconds %= 3600
+
+	minutes := seconds / 60
+
+	seconds %= 60
+
+	return TimeTicks{
+		Raw:       raw,
+		Formatted: fmt.Sprintf("Uptime: %d days, %02d hours, %02d minutes, %02d seconds", days, hours, minutes, seconds),
+	}
+}
+
+func (t TimeTicks) MarshalJSON() ([]byte, error) {
+
+	return json.Marshal(struct {
+		Raw       uint32 `json:"raw"`
+		Formatted string `json:"formatted"`
+	}{t.Raw, t.Formatted})
+}
+
+// SystemInfo mirrors the SNMP system group (RFC1213-MIB::system).
+type SystemInfo struct {
+	Name           string
+	Description    string
+	Location       string
+	ObjectID       string
+	Uptime         TimeTicks
+	InterfaceCount int
+}
+
+// Interface mirrors one row collected from ifTable, keyed by ifIndex.
+// Counters and gauges keep their native SNMP width (widened to uint64) and
+// PhysicalAddress keeps the OctetString as a net.HardwareAddr rather than a
+// pre-formatted string.
+type Interface struct {
+	Index                int
+	Name                 string
+	Alias                string
+	Description          string
+	OperationalStatus    int64
+	AdminStatus          int64
+	Speed                uint64
+	PhysicalAddress      net.HardwareAddr
+	SentOctets           uint64
+	ReceivedOctets       uint64
+	SentErrorPackets     uint64
+	ReceivedErrorPackets uint64
+	DiscardPackets       uint64
+	InPackets            uint64
+	OutPackets           uint64
+}
+
+// formatMAC renders a physical address the same way the legacy
+// (pre-typed-schema) collection path's formatMAC did: colon-separated,
+// uppercase hex, so a legacy_format consumer sees unchanged casing.
+func formatMAC(addr net.HardwareAddr) string {
+
+	return strings.ToUpper(addr.String())
+}
+
+func (i Interface) MarshalJSON() ([]byte, error) {
+
+	type alias struct {
+		Index                int    `json:"index"`
+		Name                 string `json:"name"`
+		Alias                string `json:"alias"`
+		Description          string `json:"description"`
+		OperationalStatus    int64  `json:"operationalStatus"`
+		AdminStatus          int64  `json:"adminStatus"`
+		Speed                uint64 `json:"speed"`
+		PhysicalAddress      string `json:"physicalAddress"`
+		SentOctets           uint64 `json:"sentOctets"`
+		ReceivedOctets       uint64 `json:"receivedOctets"`
+		SentErrorPackets     uint64 `json:"sentErrorPackets"`
+		ReceivedErrorPackets uint64 `json:"receivedErrorPackets"`
+		DiscardPackets       uint64 `json:"discardPackets"`
+		InPackets            uint64 `json:"inPackets"`
+		OutPackets           uint64 `json:"outPackets"`
+	}
+
+	return json.Marshal(alias{
+		Index:                i.Index,
+		Name:                 i.Name,
+		Alias:                i.Alias,
+		Description:          i.Description,
+		OperationalStatus:    i.OperationalStatus,
+		AdminStatus:          i.AdminStatus,
+		Speed:                i.Speed,
+		PhysicalAddress:      formatMAC(i.PhysicalAddress),
+		SentOctets:           i.SentOctets,
+		ReceivedOctets:       i.ReceivedOctets,
+		SentErrorPackets:     i.SentErrorPackets,
+		ReceivedErrorPackets: i.ReceivedErrorPackets,
+		DiscardPackets:       i.DiscardPackets,
+		InPackets:            i.InPackets,
+		OutPackets:           i.OutPackets,
+	})
+}
+
+// Response is the typed result of a system+interface SNMP collection. A
+// connection/system-data failure is no longer represented here: fetchOne
+// reports it as a *protocol.ProtocolError instead, so a Response only ever
+// describes a (possibly partial, see InterfacesError) successful collection.
+// When LegacyFormat is true, MarshalJSON emits the original flat
+// map[string]interface{} shape ("system.name", "interfaces", ...) so callers
+// that have not moved to the typed schema keep working unchanged.
+type Response struct {
+	System          *SystemInfo
+	Interfaces      []Interface
+	InterfacesError string
+	LegacyFormat    bool
+}
+
+func (r *Response) MarshalJSON() ([]byte, error) {
+
+	if r.LegacyFormat {
+
+		return json.Marshal(r.legacyMap())
+	}
+
+	return json.Marshal(struct {
+		System          *SystemInfo `json:"system"`
+		Interfaces      []Interface `json:"interfaces"`
+		InterfacesError string      `json:"interfacesError,omitempty"`
+	}{r.System, r.Interfaces, r.InterfacesError})
+}
+
+// legacyMap reproduces the pre-model flat response shape, keyed by the same
+// "system.name"/"interfaces" strings the original map-based collection used.
+func (r *Response) legacyMap() map[string]interface{} {
+
+	m := make(map[string]interface{})
+
+	if r.System != nil {
+
+		m["system.name"] = r.System.Name
+
+		m["system.description"] = r.System.Description
+
+		m["system.location"] = r.System.Location
+
+		m["system.objectId"] = r.System.ObjectID
+
+		m["system.uptime"] = r.System.Uptime.Formatted
+
+		m["system.interfaces"] = r.System.InterfaceCount
+	}
+
+	if r.InterfacesError != "" {
+
+		m["interfaces.error"] = r.InterfacesError
+	}
+
+	rows := make([]map[string]interface{}, 0, len(r.Interfaces))
+
+	for _, iface := range r.Interfaces {
+
+		rows = append(rows, map[string]interface{}{
+			"index":                            fmt.Sprintf("%d", iface.Index),
+			"interface.name":                   iface.Name,
+			"interface.alias":                  iface.Alias,
+			"interface.description":            iface.Description,
+			"interface.operational.status":     iface.OperationalStatus,
+			"interface.admin.status":           iface.AdminStatus,
+			"interface.speed":                  iface.Speed,
+			"interface.physical.address":       formatMAC(iface.PhysicalAddress),
+			"interface.sent.octets":            iface.SentOctets,
+			"interface.received.octets":        iface.ReceivedOctets,
+			"interface.sent.error.packets":     iface.SentErrorPackets,
+			"interface.received.error.packets": iface.ReceivedErrorPackets,
+			"interface.discard.packets":        iface.DiscardPackets,
+			"interface.in.packets":             iface.InPackets,
+			"interface.out.packets":            iface.OutPackets,
+		})
+	}
+
+	m["interfaces"] = rows
+
+	return m
+}
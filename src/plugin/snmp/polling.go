@@ -1,14 +1,19 @@
 package snmp
 
 import (
+	"GO_Plugin/src/plugin/snmp/model"
+	"GO_Plugin/src/server/protocol"
 	"GO_Plugin/src/util"
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/gosnmp/gosnmp"
-	"log"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
 )
 
 const (
@@ -17,27 +22,53 @@ const (
 	systemLocation    = "system.location"
 	systemObjectID    = "system.objectId"
 	systemUptime      = "system.uptime"
-	systemInterfaces  = "system.interfaces"
 	interfaces        = "interfaces"
 	Interface_Error   = "interfaces.error"
 	Index             = "index"
 	physicalAddress   = "interface.physical.address"
-	Message           = "message"
+	ifName            = "interface.name"
+	ifAlias           = "interface.alias"
+	ifDescription     = "interface.description"
+	ifOperStatus      = "interface.operational.status"
+	ifAdminStatus     = "interface.admin.status"
+	ifSpeed           = "interface.speed"
+	ifSentOctets      = "interface.sent.octets"
+	ifReceivedOctets  = "interface.received.octets"
+	ifSentErrors      = "interface.sent.error.packets"
+	ifReceivedErrors  = "interface.received.error.packets"
+	ifDiscardPackets  = "interface.discard.packets"
+	ifInPackets       = "interface.in.packets"
+	ifOutPackets      = "interface.out.packets"
 	OID_NOT_FOUND     = "no OIDs found in util.SNMPOids"
 	Nil               = "nil"
+	Agents            = "agents"
+
+	// LegacyFormat, when true on a request, makes a non-profile FetchSNMPData
+	// response marshal as the original flat map[string]interface{} shape
+	// instead of the typed model.Response schema.
+	LegacyFormat = "legacy_format"
 )
 
-// FetchSNMPData retrieves SNMP data for a given IP and community string, storing results in reqData.
-// @param reqData map[string]interface{} - Contains request parameters such as IP, community, version, and stores the response.
-func FetchSNMPData(reqData map[string]interface{}) {
+// FetchSNMPData retrieves SNMP data for a given IP and community string, returning a
+// protocol.Result on success or a *protocol.ProtocolError describing why collection failed.
+// If reqData carries an "agents" array instead of a single "ip", every agent is polled concurrently
+// (bounded by a per-agent rate limiter and in-flight semaphore) and the per-agent outcomes are keyed
+// by agent address in the returned map. A profile-driven request's Result is a flat
+// map[string]interface{}; the legacy (no profile) path's Result is a *model.Response, which marshals
+// as the new typed schema by default or the original flat shape when the request sets LegacyFormat.
+// @param ctx context.Context - Governs cancellation/deadline of the whole fetch; a per-request
+// deadline derived from the request's timeoutMs cancels in-flight SNMP work when it expires.
+// @param reqData map[string]interface{} - Contains request parameters such as IP, community, and version.
+func FetchSNMPData(ctx context.Context, reqData map[string]interface{}) (protocol.Result, *protocol.ProtocolError) {
 
 	if !ValidateRequest(reqData) {
 
-		reqData[Errors] = FieldMissing
+		return nil, &protocol.ProtocolError{Code: protocol.MissingField, Message: FieldMissing}
+	}
 
-		reqData[Status] = Fail
+	if rawAgents, ok := reqData[Agents].([]interface{}); ok && len(rawAgents) > 0 {
 
-		return
+		return fetchAgents(ctx, rawAgents, reqData), nil
 	}
 
 	ip := reqData[IP].(string)
@@ -46,127 +77,337 @@ func FetchSNMPData(reqData map[string]interface{}) {
 
 	version := reqData[Version].(string)
 
-	g := &gosnmp.GoSNMP{
-		Target:    ip,
-		Port:      161,
-		Community: community,
-		Timeout:   time.Millisecond * 500,
-		Retries:   1,
+	return fetchOne(ctx, ip, community, version, reqData)
+}
+
+// fetchAgents polls every agent address in rawAgents concurrently, each through its own
+// per-target rate limiter and in-flight semaphore, and returns the outcomes keyed by agent:
+// {"status": protocol.OK, "data": ...} on success, {"status": ..., "error": ...} on failure.
+func fetchAgents(ctx context.Context, rawAgents []interface{}, reqData map[string]interface{}) map[string]interface{} {
+
+	community, _ := reqData[Community].(string)
+
+	version, _ := reqData[Version].(string)
+
+	results := make(map[string]interface{})
+
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+
+	for _, rawAgent := range rawAgents {
+
+		agent, ok := rawAgent.(string)
+
+		if !ok || agent == "" {
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(agent string) {
+
+			defer wg.Done()
+
+			result, protoErr := fetchOne(ctx, agent, community, version, reqData)
+
+			mu.Lock()
+
+			if protoErr != nil {
+
+				results[agent] = map[string]interface{}{Status: protoErr.Code, Errors: protoErr.Message}
+
+			} else {
+
+				results[agent] = map[string]interface{}{Status: protocol.OK, Data: result}
+			}
+
+			mu.Unlock()
+
+		}(agent)
 	}
 
-	switch version {
+	wg.Wait()
+
+	return results
+}
 
-	case "1":
-		g.Version = gosnmp.Version1
+// fetchOne runs a single SNMP collection pass against one target, honoring the
+// target's rate limit/in-flight gate, and returns the collected Result or a
+// *protocol.ProtocolError describing the failure. A profile-driven request's Result is a flat
+// map[string]interface{}; the legacy (no profile) path's Result is a *model.Response, which
+// marshals to the typed schema by default or the original flat map when the request sets
+// LegacyFormat.
+func fetchOne(ctx context.Context, ip, community, version string, reqData map[string]interface{}) (protocol.Result, *protocol.ProtocolError) {
 
-	case "2", "2c":
-		g.Version = gosnmp.Version2c
+	gate := gateFor(ip)
 
-	case "3":
-		g.Version = gosnmp.Version3
+	if err := gate.acquire(ctx); err != nil {
 
-	default:
-		reqData[Errors] = UnsupportedSNMP
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 
-		reqData[Status] = Fail
+			return nil, &protocol.ProtocolError{Code: protocol.SNMPTimeout, Message: err.Error()}
+		}
 
-		return
+		return nil, &protocol.ProtocolError{Code: protocol.Internal, Message: err.Error()}
 	}
 
-	if err := g.Connect(); err != nil {
+	defer gate.release()
 
-		reqData[Data] = map[string]interface{}{
+	profile, err := parseProfile(reqData)
 
-			Errors: SNMPConnectFail,
+	if err != nil {
+
+		return nil, &protocol.ProtocolError{Code: protocol.InvalidRequest, Message: err.Error()}
+	}
 
-			Message: err.Error(),
+	g, err := buildSNMPClient(ctx, ip, community, version, reqData)
+
+	if err != nil {
+
+		var v3Err *V3CredentialError
+
+		if errors.As(err, &v3Err) {
+
+			return nil, &protocol.ProtocolError{Code: protocol.SNMPAuth, Message: err.Error()}
 		}
-		reqData[Status] = Fail
 
-		return
+		return nil, &protocol.ProtocolError{Code: protocol.InvalidRequest, Message: err.Error()}
+	}
+
+	if err := g.Connect(); err != nil {
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+
+			return nil, &protocol.ProtocolError{Code: protocol.SNMPTimeout, Message: err.Error()}
+		}
+
+		return nil, &protocol.ProtocolError{Code: protocol.SNMPConnect, Message: err.Error()}
 	}
 
 	defer g.Conn.Close()
 
-	systemData, err := fetchSNMPSystemData(g)
+	if profile != nil {
+
+		return collectProfile(g, profile), nil
+	}
+
+	systemData, err := fetchSNMPSystemData(ctx, g)
 
 	if err != nil {
 
-		reqData[Data] = map[string]interface{}{
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+
+			return nil, &protocol.ProtocolError{Code: protocol.SNMPTimeout, Message: err.Error()}
+		}
+
+		return nil, &protocol.ProtocolError{Code: protocol.SNMPConnect, Message: err.Error()}
+	}
+
+	resp := &model.Response{LegacyFormat: wantsLegacyFormat(reqData)}
 
-			Errors: SNMPConnectFail,
+	var ifCount int
+
+	interfacesData, err := getInterfaces(ctx, g)
+
+	if err != nil {
 
-			Message: err.Error(),
+		resp.InterfacesError = fmt.Sprintf("Error fetching interface data: %s", err)
+
+	} else {
+
+		ifCount = len(interfacesData)
+
+		resp.Interfaces = make([]model.Interface, 0, len(interfacesData))
+
+		for _, ifaceData := range interfacesData {
+
+			resp.Interfaces = append(resp.Interfaces, toModelInterface(ifaceData))
 		}
-		reqData[Status] = Success
+	}
+
+	resp.System = toModelSystemInfo(systemData, ifCount)
+
+	return resp, nil
+}
+
+// wantsLegacyFormat reports whether a request asked the typed model.Response
+// to marshal as the original flat map[string]interface{} wire format.
+func wantsLegacyFormat(reqData map[string]interface{}) bool {
 
-		return
+	legacy, _ := reqData[LegacyFormat].(bool)
+
+	return legacy
+}
+
+// toModelSystemInfo converts the loosely-typed map built by
+// fetchSNMPSystemData into a model.SystemInfo, reusing the same system.*
+// keys the legacy map used.
+func toModelSystemInfo(systemData map[string]interface{}, ifCount int) *model.SystemInfo {
+
+	info := &model.SystemInfo{InterfaceCount: ifCount}
+
+	if v, ok := systemData[systemName].(string); ok {
+
+		info.Name = v
 	}
 
-	data := map[string]interface{}{
+	if v, ok := systemData[systemDescription].(string); ok {
 
-		systemName: systemData[systemName],
+		info.Description = v
+	}
+
+	if v, ok := systemData[systemLocation].(string); ok {
 
-		systemDescription: systemData[systemDescription],
+		info.Location = v
+	}
 
-		systemLocation: systemData[systemLocation],
+	if v, ok := systemData[systemObjectID].(string); ok {
 
-		systemObjectID: systemData[systemObjectID],
+		info.ObjectID = v
 	}
 
 	if uptime, ok := systemData[systemUptime].(uint32); ok {
 
-		uptimeSeconds := uptime / 100
-
-		days := uptimeSeconds / (24 * 3600)
+		info.Uptime = model.NewTimeTicks(uptime)
+	}
 
-		uptimeSeconds %= (24 * 3600)
+	return info
+}
 
-		hours := uptimeSeconds / 3600
+// toModelInterface converts one row produced by getInterfaces into a
+// model.Interface, preserving native SNMP types (counters/gauges as uint64,
+// the physical address as a net.HardwareAddr) instead of the pre-stringified
+// map values.
+func toModelInterface(data map[string]interface{}) model.Interface {
 
-		uptimeSeconds %= 3600
+	var iface model.Interface
 
-		minutes := uptimeSeconds / 60
+	if idxStr, ok := data[Index].(string); ok {
 
-		seconds := uptimeSeconds % 60
+		if idx, err := strconv.Atoi(idxStr); err == nil {
 
-		data[systemUptime] = fmt.Sprintf("Uptime: %d days, %02d hours, %02d minutes, %02d seconds", days, hours, minutes, seconds)
+			iface.Index = idx
+		}
 	}
 
-	indexes, err := getInterfaceIndexes(g)
+	if v, ok := data[ifName].(string); ok {
 
-	if err != nil {
+		iface.Name = v
+	}
 
-		data[Interface_Error] = fmt.Sprintf("Error fetching interface indexes: %s", err)
+	if v, ok := data[ifAlias].(string); ok {
 
-		data[systemInterfaces] = 0
+		iface.Alias = v
+	}
 
-	} else {
+	if v, ok := data[ifDescription].(string); ok {
 
-		data[systemInterfaces] = len(indexes)
+		iface.Description = v
+	}
 
-		interfacesData, err := getInterfaces(g, indexes)
+	iface.OperationalStatus = toInt64(data[ifOperStatus])
 
-		if err != nil {
+	iface.AdminStatus = toInt64(data[ifAdminStatus])
 
-			data[Interface_Error] = fmt.Sprintf("Error fetching interface data: %s", err)
+	iface.Speed = toUint64(data[ifSpeed])
 
-		} else {
+	if mac, ok := data[physicalAddress].(string); ok {
 
-			data[interfaces] = interfacesData
+		if hw, err := parsePhysicalAddress(mac); err == nil {
+
+			iface.PhysicalAddress = hw
 		}
 	}
 
-	reqData[Data] = data
+	iface.SentOctets = toUint64(data[ifSentOctets])
+
+	iface.ReceivedOctets = toUint64(data[ifReceivedOctets])
+
+	iface.SentErrorPackets = toUint64(data[ifSentErrors])
+
+	iface.ReceivedErrorPackets = toUint64(data[ifReceivedErrors])
+
+	iface.DiscardPackets = toUint64(data[ifDiscardPackets])
+
+	iface.InPackets = toUint64(data[ifInPackets])
+
+	iface.OutPackets = toUint64(data[ifOutPackets])
+
+	return iface
+}
+
+// toInt64 widens the int/uint SNMP value types gosnmp decodes (Integer,
+// Counter32, Gauge32, TimeTicks, Counter64) into an int64.
+func toInt64(v interface{}) int64 {
+
+	switch n := v.(type) {
+
+	case int:
+		return int64(n)
+
+	case int64:
+		return n
+
+	case uint:
+		return int64(n)
+
+	case uint32:
+		return int64(n)
+
+	case uint64:
+		return int64(n)
+	}
+
+	return 0
+}
+
+// toUint64 widens the int/uint SNMP value types gosnmp decodes (Integer,
+// Counter32, Gauge32, TimeTicks, Counter64) into a uint64, treating negative
+// signed values as 0.
+func toUint64(v interface{}) uint64 {
+
+	switch n := v.(type) {
+
+	case uint64:
+		return n
+
+	case uint32:
+		return uint64(n)
+
+	case uint:
+		return uint64(n)
+
+	case int64:
+		if n < 0 {
+			return 0
+		}
+
+		return uint64(n)
+
+	case int:
+		if n < 0 {
+			return 0
+		}
+
+		return uint64(n)
+	}
 
-	reqData[Status] = Success
+	return 0
 }
 
 // fetchSNMPSystemData retrieves system-related SNMP data using predefined OIDs.
+// @param ctx context.Context - Checked once before the GET, so an already-expired request does no SNMP work.
 // @param g *gosnmp.GoSNMP - SNMP client used to query the target device.
 // @return map[string]interface{} - A map containing SNMP system data.
 // @return error - Error if SNMP retrieval fails or no OIDs are found.
-func fetchSNMPSystemData(g *gosnmp.GoSNMP) (map[string]interface{}, error) {
+func fetchSNMPSystemData(ctx context.Context, g *gosnmp.GoSNMP) (map[string]interface{}, error) {
+
+	if err := ctx.Err(); err != nil {
+
+		return nil, err
+	}
 
 	snmpData := make(map[string]interface{})
 
@@ -182,14 +423,14 @@ func fetchSNMPSystemData(g *gosnmp.GoSNMP) (map[string]interface{}, error) {
 		return nil, fmt.Errorf(OID_NOT_FOUND)
 	}
 
-	result, err := g.Get(oidArray)
+	variables, err := getBatched(g, oidArray)
 
 	if err != nil {
 
 		return nil, err
 	}
 
-	for i, variable := range result.Variables {
+	for i, variable := range variables {
 
 		var value interface{}
 
@@ -211,144 +452,124 @@ func fetchSNMPSystemData(g *gosnmp.GoSNMP) (map[string]interface{}, error) {
 	return snmpData, nil
 }
 
-// getInterfaceIndexes retrieves the indexes of device interfaces using SNMP walk on the specified OID.
-// @param g *gosnmp.GoSNMP - SNMP client used to query the target device.
-// @return []int - A list of interface indexes.
-// @return error - Error if SNMP walk fails or index parsing fails.
-func getInterfaceIndexes(g *gosnmp.GoSNMP) ([]int, error) {
-
-	var indexes []int
+// getInterfaces walks every column declared in util.InterfaceOids once each, via
+// GETBULK (BulkWalk; GETNEXT Walk for v1), joining the resulting columns into one row per ifIndex. This
+// replaces the old approach of one serialized g.Get per interface, which meant one
+// round trip per interface on top of one to first discover the indexes; a device
+// with hundreds of interfaces now costs one round trip per column instead.
+// @param ctx context.Context - Checked between columns so a cancelled/expired request stops
+// walking further columns instead of running the full table set to completion.
+// @param g *gosnmp.GoSNMP - SNMP client used to query the target device for interface details.
+// @return []map[string]interface{} - A list of maps where each map contains SNMP data of an interface.
+// @return error - Returns an error if walking any column fails.
+func getInterfaces(ctx context.Context, g *gosnmp.GoSNMP) ([]map[string]interface{}, error) {
 
-	targetOID := ".1.3.6.1.2.1.31.1.1.1.1"
+	rows := make(map[string]map[string]interface{})
 
-	err := g.Walk(targetOID, func(pdu gosnmp.SnmpPDU) error {
+	var order []string
 
-		oidParts := strings.Split(pdu.Name, ".")
+	for oid, field := range util.InterfaceOids {
 
-		if len(oidParts) == 0 {
+		if err := ctx.Err(); err != nil {
 
-			return fmt.Errorf("invalid OID: %s", pdu.Name)
+			return nil, err
 		}
 
-		oidSuffix := oidParts[len(oidParts)-1]
+		walkFn := func(pdu gosnmp.SnmpPDU) error {
 
-		index, err := strconv.Atoi(oidSuffix)
+			index := oidSuffix(pdu.Name, oid)
 
-		if err != nil {
+			row, ok := rows[index]
 
-			return fmt.Errorf("failed to parse index: %v", err)
-		}
+			if !ok {
 
-		indexes = append(indexes, index)
+				row = map[string]interface{}{Index: index}
 
-		return nil
-	})
+				rows[index] = row
 
-	if err != nil {
-
-		return nil, fmt.Errorf("SNMP walk failed: %v", err)
-	}
-
-	return indexes, nil
-}
-
-// getInterfaces retrieves SNMP data for multiple network interfaces based on their indexes
-// and aggregates the results. It queries each interface using its index and collects the data.
-// @param g *gosnmp.GoSNMP - SNMP client used to query the target device for interface details.
-// @param indexes []int - List of interface indexes to fetch SNMP data for each interface.
-// @return []map[string]interface{} - A list of maps where each map contains SNMP data of an interface.
-// @return error - Returns an error if SNMP data retrieval for interfaces encounters a failure.
-func getInterfaces(g *gosnmp.GoSNMP, indexes []int) ([]map[string]interface{}, error) {
-
-	interfacesData := make([]map[string]interface{}, 0, len(indexes))
+				order = append(order, index)
+			}
 
-	for _, index := range indexes {
+			if pdu.Value == nil {
 
-		data, err := getInterface(index, g)
+				return nil
+			}
 
-		if err != nil {
+			if bytes, ok := pdu.Value.([]byte); ok {
 
-			log.Printf("Error fetching interface %d: %v (continuing)", index, err)
+				if field == physicalAddress {
 
-			continue
-		}
+					row[field] = formatMAC(bytes)
 
-		interfacesData = append(interfacesData, data)
-	}
-	return interfacesData, nil
-}
+				} else {
 
-// getInterface retrieves SNMP data for a specific network interface based on its index.
-// It queries the device for interface details and formats the data accordingly.
-// @param index int - The index of the network interface to fetch SNMP data for.
-// @param g *gosnmp.GoSNMP - SNMP client used to query the target device.
-// @return map[string]interface{} - A map containing SNMP data of the interface.
-// @return error - Returns an error if SNMP data retrieval fails.
-func getInterface(index int, g *gosnmp.GoSNMP) (map[string]interface{}, error) {
+					row[field] = string(bytes)
+				}
 
-	interfaceData := make(map[string]interface{})
+			} else {
 
-	interfaceData[Index] = fmt.Sprintf("%d", index)
+				row[field] = pdu.Value
+			}
 
-	oids := make([]string, 0, len(util.InterfaceOids))
+			return nil
+		}
 
-	fields := make([]string, 0, len(util.InterfaceOids))
+		var err error
 
-	for oid, field := range util.InterfaceOids {
+		if g.Version == gosnmp.Version1 {
 
-		oids = append(oids, fmt.Sprintf("%s.%d", oid, index))
+			err = g.Walk(oid, walkFn)
 
-		fields = append(fields, field)
-	}
-
-	result, err := g.Get(oids)
+		} else {
 
-	if err != nil {
+			err = g.BulkWalk(oid, walkFn)
+		}
 
-		interfaceData[Interface_Error] = fmt.Sprintf("SNMP get failed: %v", err)
+		if err != nil {
 
-		return interfaceData, err
+			return nil, fmt.Errorf("walking interface column %s (%s): %w", field, oid, err)
+		}
 	}
 
-	for k, variable := range result.Variables {
-
-		if variable.Value == nil {
+	sort.Slice(order, func(i, j int) bool {
 
-			continue
-		}
+		oi, _ := strconv.Atoi(order[i])
 
-		field := fields[k]
+		oj, _ := strconv.Atoi(order[j])
 
-		var value interface{}
+		return oi < oj
+	})
 
-		if bytes, ok := variable.Value.([]byte); ok {
+	interfacesData := make([]map[string]interface{}, 0, len(order))
 
-			if field == physicalAddress {
+	for _, index := range order {
 
-				mac := hex.EncodeToString(bytes)
+		interfacesData = append(interfacesData, rows[index])
+	}
 
-				formattedMac := strings.ToUpper(mac)
+	return interfacesData, nil
+}
 
-				if len(formattedMac) >= 12 {
+// parsePhysicalAddress decodes a formatMAC-rendered physical address back
+// into its raw bytes. Unlike net.ParseMAC, it accepts any length rather than
+// only the fixed 6/8/20-byte IEEE 802 MAC-48/EUI-64/20-octet forms, so an
+// interface whose ifPhysAddress isn't a standard-length MAC (common on
+// tunnel/virtual interfaces) doesn't lose data in the typed model path.
+func parsePhysicalAddress(s string) (net.HardwareAddr, error) {
 
-					formattedMac = fmt.Sprintf("%s:%s:%s:%s:%s:%s",
-						formattedMac[0:2], formattedMac[2:4],
-						formattedMac[4:6], formattedMac[6:8],
-						formattedMac[8:10], formattedMac[10:12])
-				}
+	raw, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
 
-				value = formattedMac
+	if err != nil {
 
-			} else {
+		return nil, err
+	}
 
-				value = string(bytes)
-			}
-		} else {
+	return net.HardwareAddr(raw), nil
+}
 
-			value = variable.Value
-		}
+// formatMAC renders a raw OCTET STRING physical address as the colon-separated hex
+// form ("AA:BB:CC:DD:EE:FF") used throughout the interface response.
+func formatMAC(raw []byte) string {
 
-		interfaceData[field] = value
-	}
-	return interfaceData, nil
+	return strings.ToUpper(net.HardwareAddr(raw).String())
 }
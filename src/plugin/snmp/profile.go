@@ -0,0 +1,358 @@
+package snmp
+
+import (
+	"GO_Plugin/src/plugin/snmp/mib"
+	"fmt"
+	"strings"
+)
+
+// Keys understood inside a profile-driven request: the top-level
+// "fields"/"tables" arrays and the per-entry object keys within them.
+const (
+	ProfileName      = "name"
+	ProfileFields    = "fields"
+	ProfileTables    = "tables"
+	FieldName        = "name"
+	FieldOID         = "oid"
+	FieldConversion  = "conversion"
+	FieldEnum        = "enum"
+	TableIndexAsTag  = "index_as_tag"
+	TableInheritTags = "inherit_tags"
+	TableFields      = "fields"
+)
+
+// Field describes a single scalar OID to collect and, optionally, how to
+// convert its raw SNMP value before it is stored in the response map.
+// Conversion names are resolved against the snmp/convert registry; EnumMap
+// only applies when Conversion is "enum", mapping stringified raw values to
+// labels (e.g. ifOperStatus "1" -> "up"). MIBName is set when OID was
+// declared as a symbolic MIB reference (e.g. "IF-MIB::ifSpeed") so the
+// response can report both the resolved OID and the original name.
+type Field struct {
+	Name       string
+	OID        string
+	MIBName    string
+	Conversion string
+	EnumMap    map[string]string
+}
+
+// TableDef describes a conceptual SNMP table (ifTable, hrStorageTable, ...)
+// to walk. IndexAsTag controls whether the trailing OID index is kept as a
+// tag-like field on each row, and InheritTags lists scalar field names whose
+// values should be copied onto every row produced by the table walk.
+type TableDef struct {
+	Name        string
+	OID         string
+	MIBName     string
+	IndexAsTag  bool
+	InheritTags []string
+	Fields      []Field
+}
+
+// CollectionProfile is the config-driven description of what a single SNMP
+// request should collect: a measurement name, a set of scalar fields, and a
+// set of tables to walk.
+type CollectionProfile struct {
+	Name   string
+	Fields []Field
+	Tables []TableDef
+}
+
+// parseProfile builds a CollectionProfile from the "fields"/"tables" entries
+// of an inbound request, if present. It returns (nil, nil) when neither key
+// is present so callers can fall back to the legacy util.SNMPOids-based
+// collection behavior.
+func parseProfile(reqData map[string]interface{}) (*CollectionProfile, error) {
+
+	rawFields, hasFields := reqData[ProfileFields]
+
+	rawTables, hasTables := reqData[ProfileTables]
+
+	if !hasFields && !hasTables {
+
+		return nil, nil
+	}
+
+	profile := &CollectionProfile{Name: SNMPPlugin}
+
+	if name, ok := reqData[ProfileName].(string); ok && name != "" {
+
+		profile.Name = name
+	}
+
+	if hasFields {
+
+		fields, err := parseFieldList(rawFields)
+
+		if err != nil {
+
+			return nil, fmt.Errorf("fields: %w", err)
+		}
+
+		profile.Fields = fields
+	}
+
+	if hasTables {
+
+		tables, err := parseTableList(rawTables)
+
+		if err != nil {
+
+			return nil, fmt.Errorf("tables: %w", err)
+		}
+
+		profile.Tables = tables
+	}
+
+	return profile, nil
+}
+
+// parseFieldList converts the raw []interface{} decoded from JSON into a
+// slice of Field values, validating that each entry has a name and an OID.
+func parseFieldList(raw interface{}) ([]Field, error) {
+
+	items, ok := raw.([]interface{})
+
+	if !ok {
+
+		return nil, fmt.Errorf("expected an array")
+	}
+
+	fields := make([]Field, 0, len(items))
+
+	for i, item := range items {
+
+		m, ok := item.(map[string]interface{})
+
+		if !ok {
+
+			return nil, fmt.Errorf("entry %d: expected an object", i)
+		}
+
+		field, err := parseField(m)
+
+		if err != nil {
+
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// parseField reads a single {name, oid, conversion} object.
+func parseField(m map[string]interface{}) (Field, error) {
+
+	name, _ := m[FieldName].(string)
+
+	oid, _ := m[FieldOID].(string)
+
+	if name == "" || oid == "" {
+
+		return Field{}, fmt.Errorf("%q and %q are required", FieldName, FieldOID)
+	}
+
+	resolvedOID, mibName, err := resolveOID(oid)
+
+	if err != nil {
+
+		return Field{}, err
+	}
+
+	conversion, _ := m[FieldConversion].(string)
+
+	field := Field{Name: name, OID: resolvedOID, MIBName: mibName, Conversion: conversion}
+
+	if rawEnum, ok := m[FieldEnum].(map[string]interface{}); ok {
+
+		field.EnumMap = make(map[string]string, len(rawEnum))
+
+		for key, label := range rawEnum {
+
+			if labelStr, ok := label.(string); ok {
+
+				field.EnumMap[key] = labelStr
+			}
+		}
+	}
+
+	return field, nil
+}
+
+// parseTableList converts the raw []interface{} decoded from JSON into a
+// slice of TableDef values.
+func parseTableList(raw interface{}) ([]TableDef, error) {
+
+	items, ok := raw.([]interface{})
+
+	if !ok {
+
+		return nil, fmt.Errorf("expected an array")
+	}
+
+	tables := make([]TableDef, 0, len(items))
+
+	for i, item := range items {
+
+		m, ok := item.(map[string]interface{})
+
+		if !ok {
+
+			return nil, fmt.Errorf("entry %d: expected an object", i)
+		}
+
+		table, err := parseTable(m)
+
+		if err != nil {
+
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+// parseTable reads a single {name, oid, index_as_tag, inherit_tags, fields}
+// object describing one SNMP table to walk. fields may be omitted when oid
+// is a symbolic MIB reference, in which case the columns are derived from
+// the table's own MIB definition; see autoTableFields.
+func parseTable(m map[string]interface{}) (TableDef, error) {
+
+	name, _ := m[FieldName].(string)
+
+	oid, _ := m[FieldOID].(string)
+
+	if name == "" || oid == "" {
+
+		return TableDef{}, fmt.Errorf("%q and %q are required", FieldName, FieldOID)
+	}
+
+	resolvedOID, mibName, err := resolveOID(oid)
+
+	if err != nil {
+
+		return TableDef{}, err
+	}
+
+	table := TableDef{Name: name, OID: resolvedOID, MIBName: mibName}
+
+	if v, ok := m[TableIndexAsTag].(bool); ok {
+
+		table.IndexAsTag = v
+	}
+
+	if rawTags, ok := m[TableInheritTags].([]interface{}); ok {
+
+		for _, t := range rawTags {
+
+			if tag, ok := t.(string); ok {
+
+				table.InheritTags = append(table.InheritTags, tag)
+			}
+		}
+	}
+
+	rawFields, hasFields := m[TableFields]
+
+	if !hasFields {
+
+		if mibName == "" {
+
+			return TableDef{}, fmt.Errorf("table %q: %q is required when %q is not a symbolic MIB name", name, TableFields, FieldOID)
+		}
+
+		fields, err := autoTableFields(mibName)
+
+		if err != nil {
+
+			return TableDef{}, fmt.Errorf("table %q: deriving fields from %q: %w", name, mibName, err)
+		}
+
+		table.Fields = fields
+
+		return table, nil
+	}
+
+	fields, err := parseFieldList(rawFields)
+
+	if err != nil {
+
+		return TableDef{}, fmt.Errorf("table %q fields: %w", name, err)
+	}
+
+	table.Fields = fields
+
+	return table, nil
+}
+
+// autoTableFields derives a table's column Fields from its MIB definition:
+// mib.TableColumns lists the column names in order via snmptable, and each
+// is resolved to a numeric OID as "<module>::<column>" (module being the
+// part of mibName before "::"), since snmptable's header only gives bare
+// column names.
+func autoTableFields(mibName string) ([]Field, error) {
+
+	module := mibName
+
+	if idx := strings.Index(mibName, "::"); idx != -1 {
+
+		module = mibName[:idx]
+	}
+
+	columns, err := mib.TableColumns(mibName)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	fields := make([]Field, 0, len(columns))
+
+	for _, column := range columns {
+
+		column = strings.TrimSpace(column)
+
+		if column == "" {
+
+			continue
+		}
+
+		symbol := fmt.Sprintf("%s::%s", module, column)
+
+		oid, err := mib.Resolve(symbol)
+
+		if err != nil {
+
+			return nil, fmt.Errorf("resolving column %q: %w", symbol, err)
+		}
+
+		fields = append(fields, Field{Name: column, OID: oid, MIBName: symbol})
+	}
+
+	return fields, nil
+}
+
+// resolveOID resolves a declared OID, which may be a numeric string or a
+// symbolic MIB reference ("IF-MIB::ifSpeed"), into a numeric OID. It returns
+// the original reference as mibName when resolution happened, so callers can
+// preserve it in the response alongside the numeric OID.
+func resolveOID(oidOrName string) (resolvedOID, mibName string, err error) {
+
+	if !mib.IsSymbolic(oidOrName) {
+
+		return oidOrName, "", nil
+	}
+
+	resolved, err := mib.Resolve(oidOrName)
+
+	if err != nil {
+
+		return "", "", fmt.Errorf("resolving %q: %w", oidOrName, err)
+	}
+
+	return resolved, oidOrName, nil
+}
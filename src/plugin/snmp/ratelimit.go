@@ -0,0 +1,178 @@
+package snmp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// targetGate caps both the sustained request rate and the number of
+// in-flight SNMP requests against a single target, so one busy or
+// misbehaving agent cannot starve the worker pool or overrun the device.
+type targetGate struct {
+	limiter *tokenBucket
+	sem     chan struct{}
+}
+
+var (
+	gatesMu sync.Mutex
+
+	gates = make(map[string]*targetGate)
+
+	targetRateLimit   = 20.0
+	targetBurst       = 5
+	targetMaxInFlight = 4
+)
+
+// ConfigureTargetLimits sets the defaults used for every new per-target
+// gate. It should be called once at startup, before any SNMP requests are
+// served, since gates already created keep their original limits.
+func ConfigureTargetLimits(ratePerSecond float64, burst, maxInFlight int) {
+
+	gatesMu.Lock()
+
+	defer gatesMu.Unlock()
+
+	if ratePerSecond > 0 {
+
+		targetRateLimit = ratePerSecond
+	}
+
+	if burst > 0 {
+
+		targetBurst = burst
+	}
+
+	if maxInFlight > 0 {
+
+		targetMaxInFlight = maxInFlight
+	}
+}
+
+// gateFor returns the shared gate for a target, creating it on first use.
+func gateFor(target string) *targetGate {
+
+	gatesMu.Lock()
+
+	defer gatesMu.Unlock()
+
+	g, ok := gates[target]
+
+	if !ok {
+
+		g = &targetGate{
+
+			limiter: newTokenBucket(targetRateLimit, targetBurst),
+
+			sem: make(chan struct{}, targetMaxInFlight),
+		}
+
+		gates[target] = g
+	}
+
+	return g
+}
+
+// acquire blocks until a rate-limit token is available and an in-flight
+// slot for the target is free, or returns ctx.Err() once ctx is done first —
+// so a saturated/rate-limited target can't hold a caller (and the shared
+// worker-pool goroutine running it) past its request deadline or shutdown.
+func (g *targetGate) acquire(ctx context.Context) error {
+
+	select {
+
+	case g.sem <- struct{}{}:
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := g.limiter.wait(ctx); err != nil {
+
+		<-g.sem
+
+		return err
+	}
+
+	return nil
+}
+
+// release frees the in-flight slot claimed by acquire.
+func (g *targetGate) release() {
+
+	<-g.sem
+}
+
+// tokenBucket is a minimal token-bucket rate limiter: it refills at
+// ratePerSecond up to a maximum of burst tokens.
+type tokenBucket struct {
+	mu sync.Mutex
+
+	tokens float64
+
+	max float64
+
+	ratePerSecond float64
+
+	last time.Time
+}
+
+func newTokenBucket(ratePerSecond float64, burst int) *tokenBucket {
+
+	return &tokenBucket{
+
+		tokens: float64(burst),
+
+		max: float64(burst),
+
+		ratePerSecond: ratePerSecond,
+
+		last: time.Now(),
+	}
+}
+
+// allow reports whether a token was available and, if so, consumes it.
+func (b *tokenBucket) allow() bool {
+
+	b.mu.Lock()
+
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.ratePerSecond
+
+	b.last = now
+
+	if b.tokens > b.max {
+
+		b.tokens = b.max
+	}
+
+	if b.tokens < 1 {
+
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// wait blocks until a token becomes available, or returns ctx.Err() once ctx
+// is done first.
+func (b *tokenBucket) wait(ctx context.Context) error {
+
+	for !b.allow() {
+
+		select {
+
+		case <-time.After(10 * time.Millisecond):
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
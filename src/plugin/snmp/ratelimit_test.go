@@ -0,0 +1,70 @@
+package snmp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowConsumesBurstThenBlocks(t *testing.T) {
+
+	b := newTokenBucket(1, 3)
+
+	for i := 0; i < 3; i++ {
+
+		if !b.allow() {
+
+			t.Fatalf("allow() #%d: want true (within burst), got false", i)
+		}
+	}
+
+	if b.allow() {
+
+		t.Fatal("allow() after burst is exhausted: want false, got true")
+	}
+}
+
+func TestTokenBucketWaitReturnsContextErrWhenStarved(t *testing.T) {
+
+	b := newTokenBucket(0, 1)
+
+	if !b.allow() {
+
+		t.Fatal("allow() on a fresh bucket: want true")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+
+	defer cancel()
+
+	if err := b.wait(ctx); err != ctx.Err() {
+
+		t.Fatalf("wait() on an exhausted zero-rate bucket: got %v, want %v", err, ctx.Err())
+	}
+}
+
+func TestTargetGateAcquireBlocksUntilInFlightSlotFrees(t *testing.T) {
+
+	g := &targetGate{limiter: newTokenBucket(1000, 1000), sem: make(chan struct{}, 1)}
+
+	if err := g.acquire(context.Background()); err != nil {
+
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+
+	defer cancel()
+
+	if err := g.acquire(ctx); err != ctx.Err() {
+
+		t.Fatalf("acquire while the single in-flight slot is held: got %v, want %v", err, ctx.Err())
+	}
+
+	g.release()
+
+	if err := g.acquire(context.Background()); err != nil {
+
+		t.Fatalf("acquire after release: %v", err)
+	}
+}
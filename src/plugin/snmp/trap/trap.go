@@ -0,0 +1,218 @@
+// Package trap runs the SNMP trap/inform receiver: a gosnmp.TrapListener on
+// a configurable UDP port that accepts v1 Trap, v2c Trap/Inform, and v3
+// Trap/Inform (authenticated via the same USM credential-building logic the
+// v3 polling path uses). Each decoded trap is resolved into the same flat
+// map[string]interface{} shape snmp.FetchSNMPData returns, wrapped in a
+// requestType:"trap" protocol.Response, and published over a PUSH socket
+// connected to cfg.VertxResponsePort — the same channel server.StartPull's
+// workers reply over — so Vert.x consumes traps through that one stream and
+// only has to watch one socket for both request replies and unsolicited trap
+// events.
+package trap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"GO_Plugin/src/config"
+	"GO_Plugin/src/plugin/snmp"
+	"GO_Plugin/src/server/protocol"
+	"GO_Plugin/src/util"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/pebbe/zmq4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	requestTypeTrap = "trap"
+	sourceAddress   = "sourceAddress"
+)
+
+// Start binds the trap/inform listener on cfg.TrapPort and blocks until ctx
+// is cancelled, at which point the listener and PUSH socket are closed and
+// Start returns nil.
+func Start(ctx context.Context, cfg *config.Config, log *logrus.Logger) error {
+
+	push, err := zmq4.NewSocket(zmq4.PUSH)
+
+	if err != nil {
+
+		return fmt.Errorf("failed to create trap PUSH socket: %w", err)
+	}
+
+	defer push.Close()
+
+	pushAddr := fmt.Sprintf("tcp://%s:%s", cfg.VertxHost, cfg.VertxResponsePort)
+
+	if err := push.Connect(pushAddr); err != nil {
+
+		return fmt.Errorf("failed to connect trap PUSH socket to %s: %w", pushAddr, err)
+	}
+
+	listener := gosnmp.NewTrapListener()
+
+	listener.Params = buildTrapParams(cfg, log)
+
+	listener.OnNewTrap = func(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+
+		publishTrap(packet, addr, push, log)
+	}
+
+	listenAddr := fmt.Sprintf("0.0.0.0:%s", cfg.TrapPort)
+
+	go func() {
+
+		<-ctx.Done()
+
+		log.Infof("Trap listener: shutting down %s", listenAddr)
+
+		listener.Close()
+	}()
+
+	log.Infof("Trap listener bound on %s, publishing via %s", listenAddr, pushAddr)
+
+	if err := listener.Listen(listenAddr); err != nil {
+
+		if ctx.Err() != nil {
+
+			return nil
+		}
+
+		return fmt.Errorf("trap listener stopped: %w", err)
+	}
+
+	return nil
+}
+
+// buildTrapParams configures the GoSNMP instance the listener uses to parse
+// inbound packets: cfg.TrapCommunity for v1/v2c, and, when cfg declares
+// SNMPv3 USM credentials, the same security parameters snmp.BuildUSM
+// constructs for polling so authenticated/encrypted traps and informs can be
+// verified and decrypted.
+func buildTrapParams(cfg *config.Config, log *logrus.Logger) *gosnmp.GoSNMP {
+
+	params := &gosnmp.GoSNMP{
+		Community: cfg.TrapCommunity,
+		Logger:    gosnmp.NewLogger(log),
+	}
+
+	if cfg.TrapSecurityLevel == "" {
+
+		return params
+	}
+
+	usm, msgFlags, err := snmp.BuildUSM(cfg.TrapSecurityLevel, cfg.TrapSecurityName, cfg.TrapAuthProtocol, cfg.TrapAuthPassword, cfg.TrapPrivProtocol, cfg.TrapPrivPassword)
+
+	if err != nil {
+
+		log.Warnf("Trap listener: %v, v3 traps will fail authentication", err)
+
+		return params
+	}
+
+	params.Version = gosnmp.Version3
+
+	params.SecurityModel = gosnmp.UserSecurityModel
+
+	params.MsgFlags = msgFlags
+
+	params.SecurityParameters = usm
+
+	return params
+}
+
+// publishTrap resolves packet's varbinds and sends the result as a
+// requestType:"trap" protocol.Response over push. Informs are answered by
+// the TrapListener itself; this callback only reports the event onward.
+func publishTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, push *zmq4.Socket, log *logrus.Logger) {
+
+	data := resolveVarbinds(packet.Variables)
+
+	if addr != nil {
+
+		data[sourceAddress] = addr.IP.String()
+	}
+
+	payload, err := json.Marshal(protocol.NewEvent(requestTypeTrap, data))
+
+	if err != nil {
+
+		log.Errorf("Trap listener: failed to marshal trap event: %v", err)
+
+		return
+	}
+
+	if _, err := push.Send(string(payload), zmq4.DONTWAIT); err != nil {
+
+		log.Errorf("Trap listener: failed to publish trap event: %v", err)
+	}
+}
+
+// resolveVarbinds maps each varbind's OID to the same field names
+// util.SNMPOids/util.InterfaceOids resolve to for polled data, so a trap's
+// payload lines up with a polled device's. An OID matching neither map is
+// reported under its raw dotted form.
+func resolveVarbinds(variables []gosnmp.SnmpPDU) map[string]interface{} {
+
+	data := make(map[string]interface{}, len(variables))
+
+	for _, variable := range variables {
+
+		name := strings.TrimPrefix(variable.Name, ".")
+
+		value := decodeValue(variable)
+
+		if field, ok := util.SNMPOids[name]; ok {
+
+			data[field] = value
+
+			continue
+		}
+
+		if field, index, ok := interfaceColumn(name); ok {
+
+			data[fmt.Sprintf("%s.%s", field, index)] = value
+
+			continue
+		}
+
+		data[name] = value
+	}
+
+	return data
+}
+
+// interfaceColumn matches oid against util.InterfaceOids' column prefixes,
+// returning the column's field name and the trailing table index, mirroring
+// snmp.walkTable's column/index split for polled interface rows.
+func interfaceColumn(oid string) (field, index string, ok bool) {
+
+	for column, name := range util.InterfaceOids {
+
+		column = strings.TrimPrefix(column, ".")
+
+		if strings.HasPrefix(oid, column+".") {
+
+			return name, oid[len(column)+1:], true
+		}
+	}
+
+	return "", "", false
+}
+
+// decodeValue mirrors the default (no-conversion) varbind decoding used for
+// polled data: OctetString values become Go strings, everything else passes
+// through unchanged.
+func decodeValue(variable gosnmp.SnmpPDU) interface{} {
+
+	if b, ok := variable.Value.([]byte); ok {
+
+		return string(b)
+	}
+
+	return variable.Value
+}
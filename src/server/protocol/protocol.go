@@ -0,0 +1,67 @@
+// Package protocol defines the typed response envelope workers marshal back
+// over the ZMQ PUSH socket, replacing the ad-hoc error shapes each callsite
+// used to invent on its own ({"error": "..."}, {"interfaces.error": "..."},
+// {"error": "...", "details": {...}}). A StatusCode lets the Vert.x side
+// branch on the outcome instead of string-matching an error message.
+package protocol
+
+// StatusCode enumerates the outcomes a worker can report back, beyond the
+// free-form Message carried alongside it.
+type StatusCode string
+
+const (
+	OK             StatusCode = "OK"
+	InvalidRequest StatusCode = "INVALID_REQUEST"
+	MissingField   StatusCode = "MISSING_FIELD"
+	SNMPConnect    StatusCode = "SNMP_CONNECT"
+	SNMPTimeout    StatusCode = "SNMP_TIMEOUT"
+	SNMPAuth       StatusCode = "SNMP_AUTH"
+	SNMPNoSuchName StatusCode = "SNMP_NO_SUCH_NAME"
+	Internal       StatusCode = "INTERNAL"
+)
+
+// ProtocolError pairs a StatusCode with a human-readable message. It is
+// returned alongside a Response whenever the StatusCode isn't OK.
+type ProtocolError struct {
+	Code    StatusCode `json:"code"`
+	Message string     `json:"message"`
+}
+
+func (e *ProtocolError) Error() string { return e.Message }
+
+// Result is the opaque success payload of a Response: a flat
+// map[string]interface{} for profile-driven collection, a *model.Response
+// for the legacy system+interface path, or a SystemName map for discovery.
+type Result interface{}
+
+// Response is the single envelope every worker marshals back over the PUSH
+// socket: the inbound request's UUID echoed in the reply, a StatusCode the
+// Vert.x side can branch on, and either Result or Err. RequestType is only
+// set for envelopes that aren't a reply to an inbound UUID (e.g. an
+// unsolicited trap/inform), so Vert.x can tell them apart from request replies.
+type Response struct {
+	UUID        string         `json:"uuid"`
+	RequestType string         `json:"requestType,omitempty"`
+	StatusCode  StatusCode     `json:"statusCode"`
+	Err         *ProtocolError `json:"error,omitempty"`
+	Result      Result         `json:"result,omitempty"`
+}
+
+// NewResult builds an OK envelope carrying result.
+func NewResult(uuid string, result Result) Response {
+
+	return Response{UUID: uuid, StatusCode: OK, Result: result}
+}
+
+// NewError builds a failed envelope from err, reusing its StatusCode verbatim.
+func NewError(uuid string, err *ProtocolError) Response {
+
+	return Response{UUID: uuid, StatusCode: err.Code, Err: err}
+}
+
+// NewEvent builds an OK envelope for an unsolicited event (e.g. a trap),
+// tagged with requestType instead of a request UUID.
+func NewEvent(requestType string, result Result) Response {
+
+	return Response{RequestType: requestType, StatusCode: OK, Result: result}
+}
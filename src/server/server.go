@@ -3,24 +3,31 @@ package server
 import (
 	"GO_Plugin/src/config"
 	"GO_Plugin/src/plugin/snmp"
+	"GO_Plugin/src/plugin/snmp/mib"
+	"GO_Plugin/src/server/protocol"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pebbe/zmq4"
 	"github.com/sirupsen/logrus"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 )
 
 const (
-	requestType         = "requestType"
-	discovery           = "discovery"
-	polling             = "polling"
-	health_check        = "health_check"
-	ok                  = "ok"
-	numDiscoveryWorkers = 10
-	numPollingWorkers   = 100
+	requestType  = "requestType"
+	discovery    = "discovery"
+	polling      = "polling"
+	health_check = "health_check"
+	ok           = "ok"
 )
 
-func StartPull(cfg *config.Config, log *logrus.Logger) {
+// StartPull runs the ZMQ PULL/PUSH request loop until ctx is cancelled, at
+// which point the worker pools and response forwarder drain and return, and
+// the deferred socket/channel cleanup below unwinds.
+func StartPull(ctx context.Context, cfg *config.Config, log *logrus.Logger) {
 
 	pull, err := zmq4.NewSocket(zmq4.PULL)
 
@@ -67,23 +74,55 @@ func StartPull(cfg *config.Config, log *logrus.Logger) {
 	responseChan := make(chan string, 50000)
 
 	go func() {
-		for resp := range responseChan {
+		for {
+			select {
 
-			if _, err := push.Send(resp, zmq4.DONTWAIT); err != nil {
+			case <-ctx.Done():
+				return
 
-				log.Errorf("Failed to send response: %v", err)
+			case resp, ok := <-responseChan:
+
+				if !ok {
+
+					return
+				}
+
+				if _, err := push.Send(resp, zmq4.DONTWAIT); err != nil {
+
+					log.Errorf("Failed to send response: %v", err)
+				}
 			}
 		}
 	}()
 
-	for i := 0; i < numDiscoveryWorkers; i++ {
+	snmp.ConfigureTargetLimits(cfg.TargetRateLimit, cfg.TargetBurst, cfg.TargetMaxInFlight)
+
+	snmp.ConfigureMaxOIDsPerPDU(cfg.MaxOIDsPerPDU)
+
+	snmp.ConfigureBulkWalk(cfg.BulkMaxRepetitions, cfg.BulkNonRepeaters)
+
+	mib.Configure(cfg.SnmpTranslatePath, cfg.SnmpTablePath, cfg.MibDirs)
+
+	// A bounded Recv lets the loop below re-check ctx while no request arrives.
+	if err := pull.SetRcvtimeo(500 * time.Millisecond); err != nil {
+
+		log.Warnf("Failed to set PULL receive timeout: %v", err)
+	}
+
+	var workers sync.WaitGroup
+
+	for i := 0; i < cfg.DiscoveryWorkerPool; i++ {
+
+		workers.Add(1)
 
-		go discoveryWorker(i+1, requestDiscoveryChan, responseChan, log)
+		go discoveryWorker(ctx, i+1, requestDiscoveryChan, responseChan, cfg.DefaultTimeoutMs, log, &workers)
 	}
 
-	for i := 0; i < numPollingWorkers; i++ {
+	for i := 0; i < cfg.PollingWorkerPool; i++ {
 
-		go pollingWorker(i+1, requestPollingChan, responseChan, log)
+		workers.Add(1)
+
+		go pollingWorker(ctx, i+1, requestPollingChan, responseChan, cfg.DefaultTimeoutMs, log, &workers)
 	}
 
 	log.Infof("Server listening on %s...", pullAddr)
@@ -94,11 +133,32 @@ func StartPull(cfg *config.Config, log *logrus.Logger) {
 
 	defer close(responseChan)
 
+	// workers must finish draining (and stop sending on responseChan) before
+	// the close() calls above run; since defers unwind LIFO, registering this
+	// one last makes it the first to run.
+	defer workers.Wait()
+
 	for {
+		select {
+
+		case <-ctx.Done():
+
+			log.Info("StartPull: context cancelled, shutting down")
+
+			return
+
+		default:
+		}
+
 		req, err := pull.Recv(0)
 
 		if err != nil {
 
+			if zmq4.AsErrno(err) == zmq4.Errno(syscall.EAGAIN) {
+
+				continue
+			}
+
 			log.Errorf("Pull receive error: %v", err)
 
 			continue
@@ -129,38 +189,137 @@ func StartPull(cfg *config.Config, log *logrus.Logger) {
 	}
 }
 
-func discoveryWorker(id int, reqChan <-chan string, respChan chan<- string, log *logrus.Logger) {
+// requestContext derives a per-request deadline from reqData's timeoutMs field
+// (falling back to defaultTimeoutMs when absent or invalid), as a child of ctx
+// so the worker pool's own cancellation still takes precedence.
+func requestContext(ctx context.Context, reqData map[string]interface{}, defaultTimeoutMs int) (context.Context, context.CancelFunc) {
 
-	for req := range reqChan {
+	timeoutMs := defaultTimeoutMs
 
-		log.Infof("Discovery Worker %d: Processing request", id)
+	if v, ok := reqData[snmp.TimeoutMs].(float64); ok && v > 0 {
 
-		var reqData map[string]interface{}
+		timeoutMs = int(v)
+	}
 
-		_ = json.Unmarshal([]byte(req), &reqData)
+	return context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+}
+
+// requestUUID reads the inbound request's requestId field so it can be echoed back
+// verbatim on the envelope; an absent/malformed field just yields an empty UUID.
+func requestUUID(reqData map[string]interface{}) string {
 
-		snmp.Discovery(reqData)
+	uuid, _ := reqData[snmp.RequestID].(string)
 
-		jsonData, _ := json.Marshal(reqData)
+	return uuid
+}
+
+// envelope builds the protocol.Response to marshal back for a worker outcome.
+func envelope(uuid string, result protocol.Result, protoErr *protocol.ProtocolError) protocol.Response {
+
+	if protoErr != nil {
 
-		respChan <- string(jsonData)
+		return protocol.NewError(uuid, protoErr)
 	}
+
+	return protocol.NewResult(uuid, result)
 }
 
-func pollingWorker(id int, reqChan <-chan string, respChan chan<- string, log *logrus.Logger) {
+func discoveryWorker(ctx context.Context, id int, reqChan <-chan string, respChan chan<- string, defaultTimeoutMs int, log *logrus.Logger, wg *sync.WaitGroup) {
+
+	defer wg.Done()
 
-	for req := range reqChan {
+	for {
+		select {
 
-		log.Infof("Polling Worker %d: Processing request", id)
+		case <-ctx.Done():
+			return
 
-		var reqData map[string]interface{}
+		case req, ok := <-reqChan:
 
-		_ = json.Unmarshal([]byte(req), &reqData)
+			if !ok {
+
+				return
+			}
 
-		snmp.FetchSNMPData(reqData)
+			start := time.Now()
 
-		jsonData, _ := json.Marshal(reqData)
+			var reqData map[string]interface{}
 
-		respChan <- string(jsonData)
+			_ = json.Unmarshal([]byte(req), &reqData)
+
+			uuid := requestUUID(reqData)
+
+			ip, _ := reqData[snmp.IP].(string)
+
+			log.WithFields(logrus.Fields{"workerID": id, "requestUUID": uuid, "targetIP": ip}).Info("Discovery Worker: processing request")
+
+			reqCtx, cancel := requestContext(ctx, reqData, defaultTimeoutMs)
+
+			result, protoErr := snmp.Discovery(reqCtx, reqData)
+
+			cancel()
+
+			log.WithFields(logrus.Fields{"workerID": id, "requestUUID": uuid, "targetIP": ip, "timeTaken": time.Since(start).String()}).Info("Discovery Worker: request complete")
+
+			jsonData, _ := json.Marshal(envelope(uuid, result, protoErr))
+
+			select {
+
+			case respChan <- string(jsonData):
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func pollingWorker(ctx context.Context, id int, reqChan <-chan string, respChan chan<- string, defaultTimeoutMs int, log *logrus.Logger, wg *sync.WaitGroup) {
+
+	defer wg.Done()
+
+	for {
+		select {
+
+		case <-ctx.Done():
+			return
+
+		case req, ok := <-reqChan:
+
+			if !ok {
+
+				return
+			}
+
+			start := time.Now()
+
+			var reqData map[string]interface{}
+
+			_ = json.Unmarshal([]byte(req), &reqData)
+
+			uuid := requestUUID(reqData)
+
+			ip, _ := reqData[snmp.IP].(string)
+
+			log.WithFields(logrus.Fields{"workerID": id, "requestUUID": uuid, "targetIP": ip}).Info("Polling Worker: processing request")
+
+			reqCtx, cancel := requestContext(ctx, reqData, defaultTimeoutMs)
+
+			result, protoErr := snmp.FetchSNMPData(reqCtx, reqData)
+
+			cancel()
+
+			log.WithFields(logrus.Fields{"workerID": id, "requestUUID": uuid, "targetIP": ip, "timeTaken": time.Since(start).String()}).Info("Polling Worker: request complete")
+
+			jsonData, _ := json.Marshal(envelope(uuid, result, protoErr))
+
+			select {
+
+			case respChan <- string(jsonData):
+
+			case <-ctx.Done():
+				return
+			}
+		}
 	}
 }
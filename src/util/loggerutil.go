@@ -1,35 +1,53 @@
 package util
 
 import (
+	"GO_Plugin/src/config"
+	"GO_Plugin/src/util/sinks"
+
 	"github.com/sirupsen/logrus"
-	"gopkg.in/natefinch/lumberjack.v2"
-	"io"
-	"os"
 )
 
-// NewLogger creates a new logger instance with log file rotation enabled.
-func NewLogger() *logrus.Logger {
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// NewLogger builds a logrus.Logger whose destination, level, and format are
+// resolved from cfg: the sink (filesystem, console, syslog, or a TCP
+// collector such as journald) comes from util/sinks, and the format defaults
+// to structured JSON so the Vert.x pipeline can ingest worker logs
+// (workerID, requestUUID, targetIP, timeTaken, ...) as fields instead of
+// parsing formatted strings.
+func NewLogger(cfg *config.Config) *logrus.Logger {
 
 	log := logrus.New()
 
-	logFile := &lumberjack.Logger{
-		Filename:   "/home/jenil/Documents/logs/pluginlogs/app.log",
-		MaxSize:    50,
-		MaxBackups: 5,
-		MaxAge:     7,
-		Compress:   true,
+	writer, warning := sinks.Resolve(cfg)
+
+	log.SetOutput(writer)
+
+	if warning != "" {
+
+		log.Warn(warning)
 	}
 
-	multiWriter := io.MultiWriter(os.Stdout, logFile)
+	level, err := logrus.ParseLevel(cfg.LogLevel)
 
-	log.SetOutput(multiWriter)
+	if err != nil {
 
-	log.SetLevel(logrus.InfoLevel)
+		level = logrus.InfoLevel
+	}
+
+	log.SetLevel(level)
+
+	if cfg.LogFormat == LogFormatText {
 
-	log.SetFormatter(&logrus.TextFormatter{
+		log.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
 
-		FullTimestamp: true,
-	})
+	} else {
+
+		log.SetFormatter(&logrus.JSONFormatter{})
+	}
 
 	return log
 }
@@ -0,0 +1,115 @@
+// Package sinks resolves config.Config's log sink settings into an
+// io.Writer logrus can write to, so a deployment can redirect worker logs to
+// a file, the console, syslog, or a TCP collector such as journald's remote
+// listener without touching code.
+package sinks
+
+import (
+	"GO_Plugin/src/config"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net"
+	"os"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	Filesystem = "filesystem"
+	Console    = "console"
+	Syslog     = "syslog"
+	TCP        = "tcp"
+	Journald   = "journald"
+)
+
+// Sink resolves a destination io.Writer from config.Config.
+type Sink interface {
+	Writer() (io.Writer, error)
+}
+
+// Resolve builds the io.Writer for cfg.LogSinkType. An unknown type, or a
+// sink that fails to construct (e.g. a syslog/tcp dial failure), falls back
+// to the filesystem sink; the returned warning is non-empty whenever that
+// happened, so the caller can log it once the logger exists.
+func Resolve(cfg *config.Config) (io.Writer, string) {
+
+	sink, warning := lookup(cfg)
+
+	writer, err := sink.Writer()
+
+	if err == nil {
+
+		return writer, warning
+	}
+
+	fallback, _ := filesystemSink{cfg}.Writer()
+
+	return fallback, fmt.Sprintf("log sink %q failed (%v), falling back to filesystem", cfg.LogSinkType, err)
+}
+
+func lookup(cfg *config.Config) (Sink, string) {
+
+	switch cfg.LogSinkType {
+
+	case Filesystem, "":
+		return filesystemSink{cfg}, ""
+
+	case Console:
+		return consoleSink{cfg}, ""
+
+	case Syslog:
+		return syslogSink{cfg}, ""
+
+	case TCP, Journald:
+		return tcpSink{cfg}, ""
+
+	default:
+		return filesystemSink{cfg}, fmt.Sprintf("unknown log sink type %q, falling back to filesystem", cfg.LogSinkType)
+	}
+}
+
+// filesystemSink writes to a rotated, size/age-bounded log file.
+type filesystemSink struct{ cfg *config.Config }
+
+func (s filesystemSink) Writer() (io.Writer, error) {
+
+	return &lumberjack.Logger{
+		Filename:   s.cfg.LogFilename,
+		MaxSize:    s.cfg.LogMaxSize,
+		MaxBackups: s.cfg.LogMaxBackups,
+		MaxAge:     s.cfg.LogMaxAge,
+		Compress:   s.cfg.LogCompress,
+	}, nil
+}
+
+// consoleSink writes to stdout, or stderr when cfg.LogConsoleStream asks for it.
+type consoleSink struct{ cfg *config.Config }
+
+func (s consoleSink) Writer() (io.Writer, error) {
+
+	if s.cfg.LogConsoleStream == "stderr" {
+
+		return os.Stderr, nil
+	}
+
+	return os.Stdout, nil
+}
+
+// syslogSink writes to the local syslog daemon, which on most deployment
+// targets forwards into journald.
+type syslogSink struct{ cfg *config.Config }
+
+func (s syslogSink) Writer() (io.Writer, error) {
+
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "nms-plugin")
+}
+
+// tcpSink writes to a remote log collector (e.g. systemd-journal-remote)
+// listening on cfg.LogAddress.
+type tcpSink struct{ cfg *config.Config }
+
+func (s tcpSink) Writer() (io.Writer, error) {
+
+	return net.Dial("tcp", s.cfg.LogAddress)
+}